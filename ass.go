@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	assPlayResX         = 1920
+	assPlayResY         = 1080
+	assMargin           = 60
+	assOutlineWidth     = 3
+	centisecondsPerUnit = 100
+)
+
+// Override tag colours are written as &HBBGGRR&; these mirror the heatmap's medium and low confidence colours.
+const (
+	assMediumConfidenceColor = "&H00FFFF&"
+	assLowConfidenceColor    = "&H0000FF&"
+)
+
+var (
+	ErrInvalidASSColor    = errors.New("invalid ASS colour")
+	ErrInvalidASSPosition = errors.New("invalid ASS position")
+)
+
+// assAlignments maps the -ass-position names to ASS numpad alignment values (horizontally centered).
+var assAlignments = map[string]int{
+	"bottom": 2,
+	"middle": 5,
+	"top":    8,
+}
+
+var hexColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{2})([0-9a-fA-F]{2})([0-9a-fA-F]{2})$`)
+
+// parseASSColor converts an HTML-style #RRGGBB colour into the ASS &H00BBGGRR notation.
+func parseASSColor(color string) (string, error) {
+	match := hexColorPattern.FindStringSubmatch(strings.TrimSpace(color))
+	if match == nil {
+		return "", fmt.Errorf("%w: %s (expected #RRGGBB)", ErrInvalidASSColor, color)
+	}
+	return strings.ToUpper("&H00" + match[3] + match[2] + match[1]), nil
+}
+
+// validateASSStyle checks the ASS style options in config so mistakes surface before transcription starts.
+func validateASSStyle(config *Config) error {
+	for _, color := range []string{config.ASSPrimaryColor, config.ASSSecondaryColor, config.ASSOutlineColor} {
+		if _, err := parseASSColor(color); err != nil {
+			return err
+		}
+	}
+	if _, ok := assAlignments[config.ASSPosition]; !ok {
+		return fmt.Errorf("%w: %s (expected bottom, middle or top)", ErrInvalidASSPosition, config.ASSPosition)
+	}
+	return nil
+}
+
+// secondsToASSTimestamp converts floating-point seconds to ASS format H:MM:SS.cc.
+func secondsToASSTimestamp(seconds float64) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	total := int(seconds*centisecondsPerUnit + 0.5)
+	cs := total % centisecondsPerUnit
+	total /= centisecondsPerUnit
+	return fmt.Sprintf("%d:%02d:%02d.%02d", total/secondsPerHour, (total%secondsPerHour)/secondsPerMinute, total%secondsPerMinute, cs)
+}
+
+// escapeASSText neutralizes characters that ASS interprets as override blocks or escape sequences.
+func escapeASSText(text string) string {
+	return strings.NewReplacer("{", "(", "}", ")", "\\", "/").Replace(text)
+}
+
+// assConfidenceColor returns the tint colour for a word based on heatmap confidence thresholds.
+// High confidence words are left untinted.
+func assConfidenceColor(probability float64) (string, bool) {
+	switch getConfidenceColor(probability) {
+	case BrightRed:
+		return assLowConfidenceColor, true
+	case BrightYellow:
+		return assMediumConfidenceColor, true
+	}
+	return "", false
+}
+
+// toCentiseconds converts a duration in seconds to whole centiseconds for \k tags.
+func toCentiseconds(seconds float64) int {
+	if seconds <= 0 {
+		return 0
+	}
+	return int(seconds*centisecondsPerUnit + 0.5)
+}
+
+// assKaraokeText builds the dialogue text for a segment with a {\k} tag per word.
+// Each word's duration runs until the next word starts so gaps are absorbed into the preceding word.
+// When tint is set, medium and low confidence words are coloured so editors can spot them.
+func assKaraokeText(segment Segment, end float64, tint bool) string {
+	var words []Word
+	for _, word := range segment.Words {
+		if strings.TrimSpace(word.Word) != "" {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) == 0 {
+		return escapeASSText(strings.TrimSpace(segment.Text))
+	}
+
+	var text strings.Builder
+	if lead := toCentiseconds(words[0].Start - segment.Start); lead > 0 {
+		text.WriteString(fmt.Sprintf("{\\k%d}", lead))
+	}
+
+	for i, word := range words {
+		wordEnd := end
+		if i+1 < len(words) {
+			wordEnd = words[i+1].Start
+		} else if word.End > word.Start && word.End < end {
+			wordEnd = word.End
+		}
+
+		color, tinted := assConfidenceColor(word.Probability)
+		tinted = tint && tinted
+
+		if i > 0 {
+			text.WriteString(" ")
+		}
+		if tinted {
+			text.WriteString(fmt.Sprintf("{\\k%d\\1c%s}", toCentiseconds(wordEnd-word.Start), color))
+		} else {
+			text.WriteString(fmt.Sprintf("{\\k%d}", toCentiseconds(wordEnd-word.Start)))
+		}
+		text.WriteString(escapeASSText(strings.TrimSpace(word.Word)))
+		if tinted {
+			text.WriteString("{\\r}")
+		}
+	}
+
+	return text.String()
+}
+
+// writeASS renders segments as an Advanced SubStation Alpha script with karaoke timing.
+// The style block is built from the ASS options in config and every segment becomes one Dialogue event.
+func writeASS(w io.Writer, output *WhisperOutput, config *Config) error {
+	primary, err := parseASSColor(config.ASSPrimaryColor)
+	if err != nil {
+		return newError("build ASS style", err)
+	}
+	secondary, err := parseASSColor(config.ASSSecondaryColor)
+	if err != nil {
+		return newError("build ASS style", err)
+	}
+	outline, err := parseASSColor(config.ASSOutlineColor)
+	if err != nil {
+		return newError("build ASS style", err)
+	}
+	alignment, ok := assAlignments[config.ASSPosition]
+	if !ok {
+		return newError("build ASS style", fmt.Errorf("%w: %s", ErrInvalidASSPosition, config.ASSPosition))
+	}
+
+	var doc strings.Builder
+	doc.WriteString("[Script Info]\n")
+	doc.WriteString("; Generated by EchoWave\n")
+	doc.WriteString("ScriptType: v4.00+\n")
+	doc.WriteString(fmt.Sprintf("PlayResX: %d\n", assPlayResX))
+	doc.WriteString(fmt.Sprintf("PlayResY: %d\n", assPlayResY))
+	doc.WriteString("WrapStyle: 0\n")
+	doc.WriteString("ScaledBorderAndShadow: yes\n\n")
+
+	doc.WriteString("[V4+ Styles]\n")
+	doc.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, " +
+		"Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, " +
+		"Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	doc.WriteString(fmt.Sprintf("Style: Default,%s,%d,%s,%s,%s,&H80000000,0,0,0,0,100,100,0,0,1,%d,0,%d,%d,%d,%d,1\n\n",
+		config.ASSFont, config.ASSFontSize, primary, secondary, outline, assOutlineWidth, alignment, assMargin, assMargin, assMargin))
+
+	doc.WriteString("[Events]\n")
+	doc.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+
+	if _, err := io.WriteString(w, doc.String()); err != nil {
+		return newError("write ASS header", err)
+	}
+
+	for i, segment := range output.Segments {
+		if strings.TrimSpace(segment.Text) == "" && len(segment.Words) == 0 {
+			continue
+		}
+		end := segmentEnd(output.Segments, i)
+		line := fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+			secondsToASSTimestamp(segment.Start), secondsToASSTimestamp(end), assKaraokeText(segment, end, config.ASSTint))
+		if _, err := io.WriteString(w, line); err != nil {
+			return newError("write ASS content", err)
+		}
+	}
+
+	return nil
+}
@@ -24,9 +24,9 @@ var (
 )
 
 // isYouTubeURL validates if input string matches YouTube URL patterns.
-// Supports both youtube.com and youtu.be domains with optional protocol and www prefix.
+// Supports both youtube.com and youtu.be domains with optional protocol and www, m or music prefix.
 func isYouTubeURL(input string) bool {
-	re := regexp.MustCompile(`^(https?://)?(www\.)?(youtube\.com|youtu\.be)/`)
+	re := regexp.MustCompile(`^(https?://)?(www\.|m\.|music\.)?(youtube\.com|youtu\.be)/`)
 	return re.MatchString(input)
 }
 
@@ -75,11 +75,13 @@ func validateAudioFormat(format string) bool {
 	return false
 }
 
-// downloadYouTubeAudio extracts audio from YouTube URLs using yt-dlp.
+// downloadAudio extracts audio from YouTube and other yt-dlp supported URLs.
 // Creates temporary directory, downloads in specified format, and returns local file path.
+// The video's info JSON is written alongside the audio so track metadata can be read later.
+// A non-empty section limits the download to that range using yt-dlp's --download-sections syntax.
 // Verbose flag controls whether yt-dlp output is shown to user.
-func downloadYouTubeAudio(url, audioFormat string, verbose bool) (string, error) {
-	download("Downloading YouTube audio...")
+func downloadAudio(url, audioFormat, section string, verbose bool) (string, error) {
+	download("Downloading audio with yt-dlp...")
 
 	if !validateAudioFormat(audioFormat) {
 		return "", newError("validate audio format", fmt.Errorf("%w: %s", ErrUnsupportedAudioFormat, audioFormat))
@@ -91,7 +93,11 @@ func downloadYouTubeAudio(url, audioFormat string, verbose bool) (string, error)
 	}
 
 	outputPath := filepath.Join(tmpDir, "%(title)s.%(ext)s")
-	cmd := exec.Command("yt-dlp", "-x", "--audio-format", audioFormat, "-o", outputPath, url)
+	args := []string{"-x", "--audio-format", audioFormat, "--write-info-json", "-o", outputPath}
+	if section != "" {
+		args = append(args, "--download-sections", section, "--force-keyframes-at-cuts")
+	}
+	cmd := exec.Command("yt-dlp", append(args, url)...)
 
 	if !verbose {
 		cmd.Stdout = nil
@@ -100,13 +106,13 @@ func downloadYouTubeAudio(url, audioFormat string, verbose bool) (string, error)
 
 	done := make(chan bool)
 	go func() {
-		spinner("Downloading from YouTube...", downloadSpinnerDuration)
+		spinner("Downloading...", downloadSpinnerDuration)
 		for {
 			select {
 			case <-done:
 				return
 			default:
-				spinner("Downloading from YouTube...", progressSpinnerDuration)
+				spinner("Downloading...", progressSpinnerDuration)
 			}
 		}
 	}()
@@ -115,7 +121,7 @@ func downloadYouTubeAudio(url, audioFormat string, verbose bool) (string, error)
 	done <- true
 
 	if err != nil {
-		return "", newError("download YouTube audio", err)
+		return "", newError("download audio", err)
 	}
 
 	pattern := fmt.Sprintf("*.%s", audioFormat)
@@ -128,30 +134,67 @@ func downloadYouTubeAudio(url, audioFormat string, verbose bool) (string, error)
 	return matches[0], nil
 }
 
-// processAudio determines whether input is YouTube URL or local file and handles accordingly.
-// Returns audio file path and cleanup function for temporary files.
-// Cleanup function removes temporary directories created for YouTube downloads.
-func processAudio(input string, config *Config) (string, func()) {
-	var audioPath string
-	var cleanup func()
+// processAudio resolves input to a local audio file: yt-dlp downloads for YouTube and other supported
+// sites, a direct HTTP download for plain media URLs, a temporary copy of stdin for "-", or the local file itself.
+// Returns the audio file path, the untrimmed file to read track metadata from, and a cleanup function
+// that removes temporary directories created for downloads and trimmed clips.
+// With -start/-end only the requested range is downloaded, or cut out of the file with ffmpeg. A trimmed
+// clip would report its own length as the duration, so metadata comes from the file it was cut from.
+// Section downloads keep yt-dlp's info JSON, which describes the whole video.
+func processAudio(input string, config *Config) (string, string, func(), error) {
+	source, err := resolveSource(input, config.Verbose)
+	if err != nil {
+		return "", "", nil, err
+	}
 
-	if isYouTubeURL(input) {
-		var err error
+	switch source {
+	case sourceYTDLP:
+		var section string
+		if hasTimeRange(config) {
+			section = downloadSection(config)
+		}
 		sanitizedURL := sanitizeYouTubeURL(input)
-		audioPath, err = downloadYouTubeAudio(sanitizedURL, config.AudioFormat, config.Verbose)
+		audioPath, err := downloadAudio(sanitizedURL, config.AudioFormat, section, config.Verbose)
 		if err != nil {
-			exitWithError(err)
+			return "", "", nil, err
 		}
-		tempDir := filepath.Dir(audioPath)
-		cleanup = func() {
-			if err := os.RemoveAll(tempDir); err != nil {
-				fmt.Printf("⚠️ Failed to cleanup temp files: %v\n", err)
-			}
+		return audioPath, audioPath, removeTempDir(filepath.Dir(audioPath)), nil
+
+	case sourceHTTP, sourceStdin:
+		var audioPath string
+		if source == sourceStdin {
+			audioPath, err = spoolStdin()
+		} else {
+			audioPath, err = downloadHTTPAudio(input)
+		}
+		if err != nil {
+			return "", "", nil, err
+		}
+		cleanup := removeTempDir(filepath.Dir(audioPath))
+		if !hasTimeRange(config) {
+			return audioPath, audioPath, cleanup, nil
 		}
-	} else {
-		audioPath = input
-		cleanup = func() {}
+		clipPath, clipCleanup, err := trimAudio(audioPath, config)
+		if err != nil {
+			cleanup()
+			return "", "", nil, err
+		}
+		return clipPath, audioPath, func() { clipCleanup(); cleanup() }, nil
+	}
+
+	if hasTimeRange(config) {
+		clipPath, cleanup, err := trimAudio(input, config)
+		return clipPath, input, cleanup, err
 	}
 
-	return audioPath, cleanup
+	return input, input, func() {}, nil
+}
+
+// removeTempDir returns a cleanup function that deletes a temporary download directory.
+func removeTempDir(dir string) func() {
+	return func() {
+		if err := os.RemoveAll(dir); err != nil {
+			fmt.Fprintf(uiWriter, "⚠️ Failed to cleanup temp files: %v\n", err)
+		}
+	}
 }
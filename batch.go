@@ -0,0 +1,310 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Statuses of a batch item.
+const (
+	batchDone    = "done"
+	batchFailed  = "failed"
+	batchSkipped = "skipped"
+)
+
+var (
+	ErrNoMediaFiles       = errors.New("no audio or video files found")
+	ErrInvalidBatchOption = errors.New("invalid batch option")
+)
+
+// mediaExtensions are the file extensions picked up when walking directories and glob patterns.
+var mediaExtensions = []string{
+	".mp3", ".wav", ".m4a", ".aac", ".flac", ".ogg", ".opus", ".wma", ".aif", ".aiff",
+	".mp4", ".mkv", ".webm", ".mov", ".avi",
+}
+
+// maxFileNameLength keeps generated names well under the 255-byte limit of common filesystems
+// once the format extensions are added.
+const maxFileNameLength = 150
+
+// batchResult records what happened to one item of a batch run for the final summary.
+type batchResult struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// batchInput is one file or URL of a batch run. Output is the output path relative to -output-dir,
+// without extension, mirroring the file's place under the directory or glob it was found in.
+type batchInput struct {
+	Path   string
+	Output string
+}
+
+// validateWorkers checks the -workers option.
+func validateWorkers(workers int) error {
+	if workers < 1 {
+		return fmt.Errorf("%w: -workers must be at least 1", ErrInvalidBatchOption)
+	}
+	return nil
+}
+
+// hasGlobMeta reports whether path contains glob metacharacters.
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// isBatchInput reports whether a single argument expands to several files: a directory, or a glob
+// pattern that is not itself the name of an existing file.
+func isBatchInput(input string) bool {
+	if isRemoteURL(input) {
+		return false
+	}
+	info, err := os.Stat(input)
+	if err == nil {
+		return info.IsDir()
+	}
+	return hasGlobMeta(input)
+}
+
+// isMediaFile reports whether path has one of the supported audio or video extensions.
+func isMediaFile(path string) bool {
+	return slices.Contains(mediaExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// globBase returns the directory part of a glob pattern before its first wildcard, which is the
+// root the matched files are mirrored from.
+func globBase(pattern string) string {
+	elements := strings.Split(filepath.ToSlash(pattern), "/")
+	for i, element := range elements {
+		if hasGlobMeta(element) {
+			return filepath.FromSlash(filepath.Clean(strings.Join(elements[:i], "/") + "/."))
+		}
+	}
+	return filepath.Dir(pattern)
+}
+
+// walkMediaFiles lists the media files in dir, descending into subdirectories when recursive.
+// Hidden files and directories are skipped.
+func walkMediaFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isMediaFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// collectBatchInputs expands the command-line inputs into the files and URLs of a batch run.
+// Files are used as given, directories are walked for media files and glob patterns are expanded;
+// each file's output path mirrors its location relative to the directory or glob base it came from.
+// A file named by several inputs is transcribed once.
+func collectBatchInputs(args []string, recursive bool) ([]batchInput, error) {
+	var inputs []batchInput
+	seen := map[string]bool{}
+	add := func(path, root string) {
+		if seen[filepath.Clean(path)] {
+			return
+		}
+		seen[filepath.Clean(path)] = true
+
+		output := filepath.Base(path)
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			output = rel
+		}
+		inputs = append(inputs, batchInput{Path: path, Output: strings.TrimSuffix(output, filepath.Ext(output))})
+	}
+	addDir := func(dir, root string) error {
+		files, err := walkMediaFiles(dir, recursive)
+		if err != nil {
+			return newError("read input directory", err)
+		}
+		for _, path := range files {
+			add(path, root)
+		}
+		return nil
+	}
+
+	for _, arg := range args {
+		if isRemoteURL(arg) {
+			inputs = append(inputs, batchInput{Path: arg})
+			continue
+		}
+
+		if info, err := os.Stat(arg); err == nil {
+			if !info.IsDir() {
+				add(arg, filepath.Dir(arg))
+			} else if err := addDir(arg, arg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !hasGlobMeta(arg) {
+			return nil, newError("open input", fmt.Errorf("%w: %s", ErrInputNotFound, arg))
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, newError("expand input pattern", err)
+		}
+		root := globBase(arg)
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			switch {
+			case err != nil:
+				continue
+			case strings.HasPrefix(filepath.Base(match), ".") && !strings.HasPrefix(filepath.Base(arg), "."):
+				// Like the shell, wildcards do not pick up hidden files.
+				continue
+			case info.IsDir():
+				if err := addDir(match, root); err != nil {
+					return nil, err
+				}
+			case isMediaFile(match):
+				add(match, root)
+			}
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil, newError("collect inputs", fmt.Errorf("%w in %s", ErrNoMediaFiles, strings.Join(args, ", ")))
+	}
+	return inputs, nil
+}
+
+// runBatch transcribes several files and URLs through a pool of -workers goroutines. Dependencies and
+// updates are checked once by the caller. Local files are written under -output-dir mirroring the input
+// tree; a failed item does not stop the rest, and a summary table is printed at the end.
+// With more than one worker the heatmap is skipped, since concurrent output would interleave.
+func runBatch(args []string, config *Config) error {
+	inputs, err := collectBatchInputs(args, config.Recursive)
+	if err != nil {
+		return err
+	}
+	if config.Output != "" && len(inputs) > 1 {
+		return newError("transcribe batch", ErrOutputWithMultiple)
+	}
+
+	workers := min(config.Workers, len(inputs))
+	header(fmt.Sprintf("Batch: %d inputs, %d at a time", len(inputs), workers))
+
+	results := make([]batchResult, len(inputs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				input := inputs[i]
+				header(fmt.Sprintf("[%d/%d] %s", i+1, len(inputs), input.Path))
+
+				itemConfig := *config
+				itemConfig.Output = firstNonEmpty(config.Output, input.Output)
+				itemConfig.Heatmap = config.Heatmap && workers == 1
+
+				results[i] = batchResult{Name: input.Path, Status: batchDone}
+				if err := transcribeInput(input.Path, &itemConfig); err != nil {
+					errorMsg(input.Path + ": " + err.Error())
+					results[i].Status, results[i].Detail = batchFailed, err.Error()
+				}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	printBatchSummary(results)
+	return batchError("transcribe batch", results)
+}
+
+// batchError returns an error naming how many items failed, or nil when none did.
+func batchError(op string, results []batchResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Status == batchFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return newError(op, fmt.Errorf("%d of %d items failed", failed, len(results)))
+	}
+	return nil
+}
+
+// safeFileName turns a title into a file name that is valid on every common filesystem: path
+// separators, reserved characters and control characters become underscores, surrounding spaces and
+// dots are trimmed and the length is capped.
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "untitled"
+	}
+	return name
+}
+
+// printBatchSummary shows every item with its status, then the totals.
+func printBatchSummary(results []batchResult) {
+	header("Batch Summary")
+
+	counts := map[string]int{}
+	for i, result := range results {
+		counts[result.Status]++
+
+		color := BrightGreen
+		switch result.Status {
+		case batchFailed:
+			color = BrightRed
+		case batchSkipped:
+			color = BrightYellow
+		}
+
+		line := result.Name
+		if result.Detail != "" {
+			line += colorize(" — "+result.Detail, MutedColor)
+		}
+		fmt.Fprintf(uiWriter, "%s%s %s %s\n", prefix(), colorize(fmt.Sprintf("%3d", i+1), MutedColor), colorize(fmt.Sprintf("%-7s", result.Status), color), line)
+	}
+
+	fmt.Fprintln(uiWriter)
+	info(fmt.Sprintf("%d succeeded, %d failed, %d skipped", counts[batchDone], counts[batchFailed], counts[batchSkipped]))
+}
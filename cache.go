@@ -0,0 +1,467 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cacheDirEnv overrides the default cache location, for example to share one cache between machines.
+const cacheDirEnv = "ECHOWAVE_CACHE_DIR"
+
+// cacheKeyVersion is mixed into every key; bump it when the cached output changes shape so old
+// entries stop matching instead of being misread.
+const cacheKeyVersion = "echowave-cache-2"
+
+// Actions of the cache subcommand.
+const (
+	cacheList  = "ls"
+	cachePrune = "prune"
+	cacheClear = "clear"
+)
+
+var (
+	ErrInvalidCacheOption = errors.New("invalid cache option")
+	ErrUnknownCacheAction = errors.New("unknown cache action")
+)
+
+// cacheEntryPattern matches the files the cache writes, so ls, prune and clear never touch anything
+// else in a user-supplied -cache-dir.
+var (
+	cacheEntryPattern = regexp.MustCompile(`^[0-9a-f]{64}\.json$`)
+	cacheTempPattern  = regexp.MustCompile(`^[0-9a-f]{64}\.json\.tmp-`)
+)
+
+// cacheEntry is one cached backend result. The descriptive fields are only for `echowave cache ls`;
+// lookups go by the file name, which is the key.
+type cacheEntry struct {
+	Source   string         `json:"source"`
+	Backend  string         `json:"backend"`
+	Model    string         `json:"model"`
+	Language string         `json:"language"`
+	Task     string         `json:"task"`
+	Output   *WhisperOutput `json:"output"`
+}
+
+// cacheFile is an entry file on disk. Its modification time is the last time it was used, which
+// drives least-recently-used eviction.
+type cacheFile struct {
+	Path     string
+	Size     int64
+	LastUsed time.Time
+}
+
+// cachedAudio is the audio of one transcription together with its cache identity. Preprocessing runs
+// on first use, so a run answered entirely from the cache also skips vocal isolation and normalization.
+type cachedAudio struct {
+	source  string
+	hash    string
+	path    string
+	cleanup func()
+}
+
+// validateCacheSize checks the -cache-size option.
+func validateCacheSize(megabytes int) error {
+	if megabytes < 1 {
+		return fmt.Errorf("%w: -cache-size must be at least 1 MB", ErrInvalidCacheOption)
+	}
+	return nil
+}
+
+// cacheDir returns the cache location: -cache-dir, $ECHOWAVE_CACHE_DIR, or echowave under the
+// user cache directory (~/.cache on Linux, ~/Library/Caches on macOS, %LocalAppData% on Windows).
+func cacheDir(config *Config) (string, error) {
+	if dir := firstNonEmpty(config.CacheDir, os.Getenv(cacheDirEnv)); dir != "" {
+		return dir, nil
+	}
+	userDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userDir, "echowave"), nil
+}
+
+// hashAudioFile returns the hex SHA-256 of a file's bytes.
+func hashAudioFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cacheKey combines the audio hash with every option that changes what the backend returns, including
+// the backend's own inputs such as its executable, model file or server. Options applied after
+// transcription, such as output formats, lyrics alignment and hallucination filtering, are left out so
+// changing them is answered from the cache.
+func cacheKey(audioHash string, transcriber Transcriber, config *Config) string {
+	separator := ""
+	if config.IsolateVocals {
+		separator = config.Separator
+	}
+	parts := []string{
+		cacheKeyVersion,
+		audioHash,
+		config.Backend,
+		config.Model,
+		config.Language,
+		config.Task,
+		config.Prompt,
+		separator,
+		fmt.Sprint(config.Normalize, config.HighPass, config.LowPass),
+		fmt.Sprint(effectiveChunkLength(config)),
+	}
+	parts = append(parts, transcriber.CacheKey(config)...)
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileIdentity describes a file by path, size and modification time, so replacing it changes the
+// cache key. A file that cannot be read is described by its path alone.
+func fileIdentity(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return path
+	}
+	return fmt.Sprintf("%s %d %d", path, info.Size(), info.ModTime().UnixNano())
+}
+
+// commandIdentity describes the executable a command runs, so upgrading or switching it changes the
+// cache key.
+func commandIdentity(command string) string {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return command
+	}
+	return fileIdentity(path)
+}
+
+// newCachedAudio prepares audioPath for transcription. With -cache=false, or when the audio cannot be
+// hashed, every pass goes to the backend.
+func newCachedAudio(audioPath string, config *Config) *cachedAudio {
+	audio := &cachedAudio{source: audioPath}
+	if !config.Cache {
+		return audio
+	}
+
+	hash, err := hashAudioFile(audioPath)
+	if err != nil {
+		warning("Transcription cache disabled, could not hash audio: " + err.Error())
+		return audio
+	}
+	audio.hash = hash
+	return audio
+}
+
+// transcribe returns the backend output for the audio under config, from the cache when an entry with
+// the same key exists. Fresh results are stored before the caller modifies them.
+func (a *cachedAudio) transcribe(transcriber Transcriber, config *Config) (*WhisperOutput, error) {
+	var dir, key string
+	if a.hash != "" {
+		var err error
+		if dir, err = cacheDir(config); err != nil {
+			warning("Transcription cache disabled: " + err.Error())
+		} else {
+			key = cacheKey(a.hash, transcriber, config)
+			if output, ok := loadCacheEntry(dir, key); ok {
+				success("Loaded transcription from cache " + colorize(key[:12], MutedColor))
+				return output, nil
+			}
+		}
+	}
+
+	if a.path == "" {
+		path, cleanup, err := preprocessAudio(a.source, config)
+		if err != nil {
+			return nil, err
+		}
+		a.path, a.cleanup = path, cleanup
+	}
+
+	output, err := transcribeAudio(transcriber, a.path, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if key != "" {
+		entry := cacheEntry{
+			Source:   filepath.Base(a.source),
+			Backend:  config.Backend,
+			Model:    config.Model,
+			Language: firstNonEmpty(output.Language, config.Language),
+			Task:     config.Task,
+			Output:   output,
+		}
+		if err := storeCacheEntry(dir, key, entry, int64(config.CacheSize)<<20); err != nil {
+			warning("Failed to cache transcription: " + err.Error())
+		}
+	}
+	return output, nil
+}
+
+// close removes the preprocessed files, if preprocessing ran.
+func (a *cachedAudio) close() {
+	if a.cleanup != nil {
+		a.cleanup()
+	}
+}
+
+// loadCacheEntry reads the output stored under key and marks the entry as just used.
+// Unreadable entries count as misses and are overwritten by the next store.
+func loadCacheEntry(dir, key string) (*WhisperOutput, bool) {
+	path := filepath.Join(dir, key+".json")
+	entry, ok := loadCacheEntryFile(path)
+	if !ok {
+		return nil, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return entry.Output, true
+}
+
+// storeCacheEntry writes an entry under key and then evicts the least recently used entries beyond
+// maxBytes. The file is written under a temporary name and renamed, so concurrent batch workers never
+// read a partial entry.
+func storeCacheEntry(dir, key string, entry cacheEntry, maxBytes int64) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, key+".json.tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, key+".json"))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	_, _, err = evictCacheEntries(dir, maxBytes)
+	return err
+}
+
+// listCacheFiles returns the cache entries in dir, least recently used first. A missing directory is
+// an empty cache.
+func listCacheFiles(dir string) ([]cacheFile, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []cacheFile
+	for _, dirEntry := range dirEntries {
+		if !cacheEntryPattern.MatchString(dirEntry.Name()) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{Path: filepath.Join(dir, dirEntry.Name()), Size: info.Size(), LastUsed: info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].LastUsed.Before(files[j].LastUsed) })
+	return files, nil
+}
+
+// evictCacheEntries removes least recently used entries until the cache fits in maxBytes, and returns
+// how many entries and bytes were removed. Entries already removed by another process are skipped.
+func evictCacheEntries(dir string, maxBytes int64) (int, int64, error) {
+	files, err := listCacheFiles(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.Size
+	}
+
+	removed, freed := 0, int64(0)
+	for _, file := range files {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.Remove(file.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, freed, err
+		}
+		total -= file.Size
+		removed++
+		freed += file.Size
+	}
+	return removed, freed, nil
+}
+
+// formatCacheSize renders a byte count for cache listings, in KB below a megabyte.
+func formatCacheSize(bytes int64) string {
+	if bytes < 1<<20 {
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	}
+	return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+}
+
+// runCache carries out `echowave cache ls|prune|clear`, defaulting to ls. ls lists the entries with their source and
+// options, prune removes unreadable entries and leftovers of interrupted writes and then applies
+// -cache-size, and clear removes every entry.
+func runCache(config *Config) error {
+	dir, err := cacheDir(config)
+	if err != nil {
+		return newError("locate cache directory", err)
+	}
+
+	action := firstNonEmpty(config.CacheAction, cacheList)
+	switch action {
+	case cacheList:
+		return listCache(dir, int64(config.CacheSize)<<20)
+	case cachePrune:
+		return pruneCache(dir, int64(config.CacheSize)<<20)
+	case cacheClear:
+		return clearCache(dir)
+	}
+	return newError("run cache command", fmt.Errorf("%w: %q (valid: %s, %s, %s)", ErrUnknownCacheAction, action, cacheList, cachePrune, cacheClear))
+}
+
+// listCache prints every entry, most recently used first, followed by the total size.
+func listCache(dir string, maxBytes int64) error {
+	files, err := listCacheFiles(dir)
+	if err != nil {
+		return newError("read cache directory", err)
+	}
+
+	header("Transcription cache: " + dir)
+	var total int64
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		total += file.Size
+
+		detail := colorize("unreadable", ErrorColor)
+		if entry, ok := loadCacheEntryFile(file.Path); ok {
+			detail = entry.Source + colorize(fmt.Sprintf(" — %s %s, %s, %s", entry.Backend, entry.Model, entry.Language, entry.Task), MutedColor)
+		}
+
+		key := strings.TrimSuffix(filepath.Base(file.Path), ".json")
+		fmt.Fprintf(uiWriter, "%s%s %s %9s %s\n", prefix(), colorize(key[:12], MutedColor), file.LastUsed.Format("2006-01-02 15:04"), formatCacheSize(file.Size), detail)
+	}
+
+	fmt.Fprintln(uiWriter)
+	info(fmt.Sprintf("%d entries, %s of %s", len(files), formatCacheSize(total), formatCacheSize(maxBytes)))
+	return nil
+}
+
+// pruneCache removes unreadable entries and temporary files, then evicts entries beyond maxBytes.
+func pruneCache(dir string, maxBytes int64) error {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return newError("read cache directory", err)
+	}
+
+	removed, freed := 0, int64(0)
+	for _, dirEntry := range dirEntries {
+		path := filepath.Join(dir, dirEntry.Name())
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case cacheTempPattern.MatchString(dirEntry.Name()):
+			// Fresh temporary files may belong to a run still writing them.
+			if time.Since(info.ModTime()) < time.Hour {
+				continue
+			}
+		case cacheEntryPattern.MatchString(dirEntry.Name()):
+			if _, ok := loadCacheEntryFile(path); ok {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if err := os.Remove(path); err != nil {
+			return newError("prune cache", err)
+		}
+		removed++
+		freed += info.Size()
+	}
+
+	evicted, evictedBytes, err := evictCacheEntries(dir, maxBytes)
+	if err != nil {
+		return newError("prune cache", err)
+	}
+
+	success(fmt.Sprintf("Removed %d unreadable and %d least recently used entries, freeing %s", removed, evicted, formatCacheSize(freed+evictedBytes)))
+	return nil
+}
+
+// loadCacheEntryFile reads an entry file without updating its last-used time.
+func loadCacheEntryFile(path string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Output == nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// clearCache removes every entry and temporary file, leaving anything else in the directory alone.
+func clearCache(dir string) error {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return newError("read cache directory", err)
+	}
+
+	removed, freed := 0, int64(0)
+	for _, dirEntry := range dirEntries {
+		if !cacheEntryPattern.MatchString(dirEntry.Name()) && !cacheTempPattern.MatchString(dirEntry.Name()) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, dirEntry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return newError("clear cache", err)
+		}
+		removed++
+		freed += info.Size()
+	}
+
+	success(fmt.Sprintf("Removed %d cache entries, freeing %s", removed, formatCacheSize(freed)))
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyFollowsBackendInputs(t *testing.T) {
+	base := Config{Backend: "openai", Model: "whisper-1", Language: "en", Task: taskTranscribe}
+	openai := openAITranscriber{}
+
+	t.Setenv(apiBaseURLEnv, "http://one.example/v1")
+	first := cacheKey("audio", openai, &base)
+	if again := cacheKey("audio", openai, &base); again != first {
+		t.Fatal("cacheKey is not stable")
+	}
+
+	t.Setenv(apiBaseURLEnv, "http://two.example/v1")
+	if cacheKey("audio", openai, &base) == first {
+		t.Error("servers set through the environment share a cache key")
+	}
+
+	postProcessing := base
+	postProcessing.Formats = []string{"srt"}
+	postProcessing.Hallucinations = hallucinationsOff
+	if cacheKey("audio", openai, &postProcessing) != cacheKey("audio", openai, &base) {
+		t.Error("output-only options change the cache key")
+	}
+}
+
+func TestCacheKeyFollowsWhisperCppModelFile(t *testing.T) {
+	dir := t.TempDir()
+	modelPath := filepath.Join(dir, "ggml-base.bin")
+	if err := os.WriteFile(modelPath, []byte("model one"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{Backend: "whisper-cpp", Model: "base", ModelDir: dir, Language: "en", Task: taskTranscribe}
+	whisperCpp := whisperCppTranscriber{}
+	first := cacheKey("audio", whisperCpp, &config)
+
+	if err := os.WriteFile(modelPath, []byte("model two, retrained"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(modelPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if cacheKey("audio", whisperCpp, &config) == first {
+		t.Error("a replaced model file keeps its cache key")
+	}
+
+	otherDir := config
+	otherDir.ModelDir = t.TempDir()
+	if cacheKey("audio", whisperCpp, &otherDir) == cacheKey("audio", whisperCpp, &config) {
+		t.Error("a different -model-dir keeps the cache key")
+	}
+
+	otherBinary := config
+	otherBinary.WhisperCppBinary = "/opt/whisper.cpp/build/bin/whisper-cli"
+	if cacheKey("audio", whisperCpp, &otherBinary) == cacheKey("audio", whisperCpp, &config) {
+		t.Error("a different -whisper-cpp-bin keeps the cache key")
+	}
+}
+
+func TestEvictCacheEntriesRemovesLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	names := []string{"a", "b", "c"}
+	for i, name := range names {
+		path := filepath.Join(dir, strings.Repeat(name, 64)+".json")
+		if err := os.WriteFile(path, make([]byte, 1000), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		used := now.Add(time.Duration(i-len(names)) * time.Hour)
+		if err := os.Chtimes(path, used, used); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, freed, err := evictCacheEntries(dir, 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 || freed != 1000 {
+		t.Errorf("removed %d entries and %d bytes, want 1 and 1000", removed, freed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, strings.Repeat("a", 64)+".json")); !os.IsNotExist(err) {
+		t.Error("the least recently used entry survived eviction")
+	}
+}
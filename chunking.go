@@ -0,0 +1,356 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultChunkLength = 300.0
+	// chunkOverlap is the audio added on each side of a chunk so words cut at a boundary are heard whole.
+	chunkOverlap = 2.0
+	// silenceNoiseFloor and silenceMinDuration tune ffmpeg silencedetect for music: gaps between songs
+	// and pauses between phrases qualify, sustained quiet passages do not.
+	silenceNoiseFloor  = "-35dB"
+	silenceMinDuration = 0.5
+)
+
+var ErrInvalidChunking = errors.New("invalid chunking option")
+
+var (
+	silenceStartPattern = regexp.MustCompile(`silence_start: (-?[0-9.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end: (-?[0-9.]+)`)
+)
+
+// audioChunk is one piece of a long recording. The chunk owns [Start, End) of the original timeline;
+// its audio file also covers chunkOverlap seconds either side, beginning at AudioStart.
+type audioChunk struct {
+	Start      float64
+	End        float64
+	AudioStart float64
+	Path       string
+}
+
+// validateChunking checks the -jobs and -chunk-length options.
+func validateChunking(jobs int, chunkLength float64) error {
+	if jobs < 1 {
+		return fmt.Errorf("%w: -jobs must be at least 1", ErrInvalidChunking)
+	}
+	if chunkLength < 0 || (chunkLength > 0 && chunkLength < 4*chunkOverlap) {
+		return fmt.Errorf("%w: -chunk-length must be 0 or at least %.0f seconds", ErrInvalidChunking, 4*chunkOverlap)
+	}
+	return nil
+}
+
+// effectiveChunkLength returns the chunk length to use, or zero when the audio is transcribed whole.
+// Setting -jobs above 1 without -chunk-length chunks at the default length.
+func effectiveChunkLength(config *Config) float64 {
+	if config.ChunkLength > 0 {
+		return config.ChunkLength
+	}
+	if config.Jobs > 1 {
+		return defaultChunkLength
+	}
+	return 0
+}
+
+// detectSilences runs ffmpeg silencedetect and returns the midpoint of every silent stretch.
+func detectSilences(audioPath string) ([]float64, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%s:d=%g", silenceNoiseFloor, silenceMinDuration)
+	cmd := exec.Command("ffmpeg", "-hide_banner", "-nostats", "-i", audioPath, "-af", filter, "-f", "null", "-")
+
+	var logs bytes.Buffer
+	cmd.Stderr = &logs
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	var midpoints []float64
+	start := -1.0
+	for _, line := range strings.Split(logs.String(), "\n") {
+		if match := silenceStartPattern.FindStringSubmatch(line); match != nil {
+			start, _ = strconv.ParseFloat(match[1], 64)
+			continue
+		}
+		if match := silenceEndPattern.FindStringSubmatch(line); match != nil && start >= 0 {
+			end, _ := strconv.ParseFloat(match[1], 64)
+			midpoints = append(midpoints, (start+end)/2)
+			start = -1
+		}
+	}
+	return midpoints, nil
+}
+
+// planChunkBoundaries picks cut points roughly every chunkLength seconds. Each cut goes at the silence
+// closest to the target within half a chunk length either way; without a nearby silence the audio is
+// cut at the target and the overlap keeps the split words intact.
+func planChunkBoundaries(duration, chunkLength float64, silences []float64) []float64 {
+	boundaries := []float64{0}
+	start := 0.0
+
+	for duration-start > chunkLength*1.5 {
+		target := start + chunkLength
+		cut := target
+		best := chunkLength / 2
+		for _, silence := range silences {
+			if distance := math.Abs(silence - target); distance < best {
+				cut = silence
+				best = distance
+			}
+		}
+		boundaries = append(boundaries, cut)
+		start = cut
+	}
+
+	return append(boundaries, duration)
+}
+
+// extractChunks writes each chunk, with its overlap, as a 16 kHz mono WAV into dir.
+func extractChunks(audioPath, dir string, boundaries []float64, verbose bool) ([]audioChunk, error) {
+	duration := boundaries[len(boundaries)-1]
+	chunks := make([]audioChunk, 0, len(boundaries)-1)
+
+	for i := 0; i+1 < len(boundaries); i++ {
+		chunk := audioChunk{
+			Start:      boundaries[i],
+			End:        boundaries[i+1],
+			AudioStart: max(boundaries[i]-chunkOverlap, 0),
+			Path:       filepath.Join(dir, fmt.Sprintf("chunk-%03d.wav", i)),
+		}
+		audioEnd := min(chunk.End+chunkOverlap, duration)
+
+		cmd := exec.Command("ffmpeg", "-y", "-loglevel", "error", "-ss", strconv.FormatFloat(chunk.AudioStart, 'f', 3, 64),
+			"-t", strconv.FormatFloat(audioEnd-chunk.AudioStart, 'f', 3, 64), "-i", audioPath,
+			"-vn", "-ar", fmt.Sprint(normalizedSampleRate), "-ac", "1", "-c:a", "pcm_s16le", chunk.Path)
+		if verbose {
+			cmd.Stdout = uiWriter
+			cmd.Stderr = os.Stderr
+		}
+		if err := cmd.Run(); err != nil {
+			return nil, newError(fmt.Sprintf("extract chunk %d", i+1), err)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// pinChunkLanguage handles -language=auto for chunked runs. Chunks are transcribed one at a time until
+// one reports a language, which is then pinned for the remaining chunks so every chunk is decoded in the
+// same language instead of each detecting its own. It returns the config for the remaining chunks and
+// the index of the first chunk not yet transcribed.
+func pinChunkLanguage(transcriber Transcriber, chunks []audioChunk, config *Config, results []*WhisperOutput) (*Config, int, error) {
+	if config.Language != autoLanguage {
+		return config, 0, nil
+	}
+
+	for i, chunk := range chunks {
+		output, err := transcriber.Transcribe(chunk.Path, config)
+		if errors.Is(err, ErrNoSegmentsFound) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, newError(fmt.Sprintf("transcribe chunk %d", i+1), err)
+		}
+		results[i] = output
+
+		language := normalizeLanguageCode(output.Language)
+		if language == "" {
+			warning("Backend reported no language for chunk " + fmt.Sprint(i+1) + ", remaining chunks detect their own")
+			return config, i + 1, nil
+		}
+		info(fmt.Sprintf("Detected %s on chunk %d, pinning it for the remaining chunks", language, i+1))
+		pinned := *config
+		pinned.Language = language
+		return &pinned, i + 1, nil
+	}
+	return config, len(chunks), nil
+}
+
+// transcribeChunks runs the backend over every chunk with at most config.Jobs transcriptions at once.
+// Chunks without speech, such as instrumental breaks, yield no result rather than an error. Any other
+// error stops new chunks from starting and is returned once running ones finish. Under -language=auto
+// the language is detected once, see pinChunkLanguage.
+func transcribeChunks(transcriber Transcriber, chunks []audioChunk, config *Config) ([]*WhisperOutput, error) {
+	results := make([]*WhisperOutput, len(chunks))
+	config, first, err := pinChunkLanguage(transcriber, chunks, config, results)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for worker := 0; worker < min(config.Jobs, len(chunks)-first); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				output, err := transcriber.Transcribe(chunks[i].Path, config)
+				if errors.Is(err, ErrNoSegmentsFound) {
+					output, err = nil, nil
+				}
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = newError(fmt.Sprintf("transcribe chunk %d", i+1), err)
+				}
+				results[i] = output
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := first; i < len(chunks); i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, firstErr
+}
+
+// ownsTime reports whether a time on the original timeline falls in the part of the recording the chunk owns.
+func (chunk audioChunk) ownsTime(t float64) bool {
+	return t >= chunk.Start && t < chunk.End
+}
+
+// trimSegmentToChunk shifts a chunk's segment onto the original timeline and keeps only the words the
+// chunk owns, judged by each word's midpoint. A segment straddling a boundary is cut there, and the
+// neighbouring chunk contributes the other half, so every word is kept exactly once. Segments without
+// word timings are kept or dropped whole by their midpoint. Returns false when nothing is left.
+func trimSegmentToChunk(segment Segment, chunk audioChunk) (Segment, bool) {
+	offset := chunk.AudioStart
+	segment.Start += offset
+	segment.End += offset
+	segment.Raw = segment.Raw.without(seekField)
+
+	if len(segment.Words) == 0 {
+		segment.Words = nil
+		return segment, chunk.ownsTime((segment.Start + segment.End) / 2)
+	}
+
+	words := make([]Word, 0, len(segment.Words))
+	for _, word := range segment.Words {
+		word.Start += offset
+		word.End += offset
+		if chunk.ownsTime((word.Start + word.End) / 2) {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return segment, false
+	}
+
+	if len(words) < len(segment.Words) {
+		var text strings.Builder
+		for _, word := range words {
+			text.WriteString(word.Word)
+		}
+		segment.Text = text.String()
+		segment.Start = words[0].Start
+		segment.End = words[len(words)-1].End
+		// Whisper's tokens describe the whole segment and no longer match the trimmed text.
+		segment.Raw = segment.Raw.without("tokens")
+	}
+	segment.Words = words
+	return segment, true
+}
+
+// stitchChunks merges chunk transcriptions onto the original timeline. Times are shifted by each
+// chunk's audio offset and every chunk keeps only the words inside [Start, End) it owns, which removes
+// the duplicates transcribed twice in the overlap between neighbouring chunks.
+func stitchChunks(chunks []audioChunk, results []*WhisperOutput) *WhisperOutput {
+	stitched := &WhisperOutput{}
+	var texts []string
+
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		if stitched.Language == "" {
+			stitched.Language = result.Language
+			stitched.LanguageProbability = result.LanguageProbability
+		}
+
+		for _, segment := range result.Segments {
+			segment, ok := trimSegmentToChunk(segment, chunks[i])
+			if !ok {
+				continue
+			}
+			stitched.Segments = append(stitched.Segments, segment)
+			texts = append(texts, strings.TrimSpace(segment.Text))
+		}
+	}
+
+	stitched.Text = strings.Join(texts, " ")
+	return stitched
+}
+
+// transcribeAudio runs the backend over audioPath. Long recordings are split at silences into chunks
+// that are transcribed in parallel (-jobs) and stitched back together; short files and runs without
+// chunking go to the backend in one piece.
+func transcribeAudio(transcriber Transcriber, audioPath string, config *Config) (*WhisperOutput, error) {
+	chunkLength := effectiveChunkLength(config)
+	if chunkLength == 0 {
+		return transcriber.Transcribe(audioPath, config)
+	}
+
+	metadata, err := readFFprobeMetadata(audioPath)
+	if err != nil {
+		return nil, newError("read audio duration", err)
+	}
+	if metadata.Duration <= chunkLength*1.5 {
+		return transcriber.Transcribe(audioPath, config)
+	}
+
+	step("Detecting silences for chunking...")
+	silences, err := detectSilences(audioPath)
+	if err != nil {
+		warning("Silence detection failed, cutting at fixed intervals: " + err.Error())
+	}
+
+	tmpDir, err := os.MkdirTemp("", "echowave-chunks-*")
+	if err != nil {
+		return nil, newError("create temp directory", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	chunks, err := extractChunks(audioPath, tmpDir, planChunkBoundaries(metadata.Duration, chunkLength, silences), config.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	processing(fmt.Sprintf("Transcribing %d chunks with %d parallel jobs...", len(chunks), min(config.Jobs, len(chunks))))
+	results, err := transcribeChunks(transcriber, chunks, config)
+	if err != nil {
+		return nil, err
+	}
+
+	output := stitchChunks(chunks, results)
+	if len(output.Segments) == 0 {
+		return nil, newError("process transcription", ErrNoSegmentsFound)
+	}
+
+	success(fmt.Sprintf("Stitched %d segments from %d chunks", len(output.Segments), len(chunks)))
+	return output, nil
+}
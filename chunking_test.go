@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStitchChunksTrimsOverlapAtWordLevel(t *testing.T) {
+	// The boundary at 10s falls inside "one two three four". Both chunks hear the whole line in the
+	// overlap; the first owns "one two", the second "three four".
+	chunks := []audioChunk{
+		{Start: 0, End: 10, AudioStart: 0},
+		{Start: 10, End: 20, AudioStart: 8},
+	}
+	line := func(offset float64) Segment {
+		return Segment{
+			Start: 8.5 - offset, End: 11.5 - offset, Text: " one two three four",
+			Words: []Word{
+				{Word: " one", Start: 8.5 - offset, End: 9.0 - offset},
+				{Word: " two", Start: 9.0 - offset, End: 9.8 - offset},
+				{Word: " three", Start: 10.1 - offset, End: 10.8 - offset},
+				{Word: " four", Start: 10.8 - offset, End: 11.5 - offset},
+			},
+			Raw: rawFields{"tokens": []byte("[1,2,3,4]"), "temperature": []byte("0")},
+		}
+	}
+	results := []*WhisperOutput{
+		{Language: "en", Segments: []Segment{{Start: 1, End: 2, Text: " intro", Words: []Word{{Word: " intro", Start: 1, End: 2}}}, line(0)}},
+		{Language: "en", Segments: []Segment{line(8), {Start: 6, End: 7, Text: " outro", Words: []Word{{Word: " outro", Start: 6, End: 7}}}}},
+	}
+
+	stitched := stitchChunks(chunks, results)
+
+	var texts []string
+	for _, segment := range stitched.Segments {
+		texts = append(texts, strings.TrimSpace(segment.Text))
+	}
+	if got, want := strings.Join(texts, " | "), "intro | one two | three four | outro"; got != want {
+		t.Fatalf("stitched segments = %q, want %q", got, want)
+	}
+	if stitched.Text != "intro one two three four outro" {
+		t.Errorf("text = %q", stitched.Text)
+	}
+
+	second := stitched.Segments[2]
+	if second.Start != 10.1 || second.End != 11.5 || second.Words[0].Start != 10.1 {
+		t.Errorf("second half not on the original timeline: %+v", second)
+	}
+	if _, ok := second.Raw["tokens"]; ok {
+		t.Error("trimmed segment kept the tokens of the whole segment")
+	}
+	if _, ok := results[1].Segments[0].Raw["tokens"]; !ok {
+		t.Error("trimming modified the chunk result's raw fields")
+	}
+	if _, ok := stitched.Segments[0].Raw["tokens"]; ok {
+		t.Error("untrimmed segment gained raw fields")
+	}
+}
+
+func TestStitchChunksWithoutWordsUsesMidpoint(t *testing.T) {
+	chunks := []audioChunk{
+		{Start: 0, End: 10, AudioStart: 0},
+		{Start: 10, End: 20, AudioStart: 8},
+	}
+	results := []*WhisperOutput{
+		{Segments: []Segment{{Start: 9, End: 12, Text: "straddles"}}},
+		{Segments: []Segment{{Start: 1, End: 4, Text: "straddles"}}},
+	}
+
+	stitched := stitchChunks(chunks, results)
+	if len(stitched.Segments) != 1 {
+		t.Fatalf("got %d segments, want exactly 1", len(stitched.Segments))
+	}
+	if stitched.Segments[0].Start != 9 {
+		t.Errorf("start = %v, want 9", stitched.Segments[0].Start)
+	}
+}
+
+// languageTranscriber records the language each chunk was transcribed with and detects "ja" when asked for auto.
+type languageTranscriber struct {
+	mu        sync.Mutex
+	languages map[string]string
+}
+
+func (*languageTranscriber) Name() string                      { return "test" }
+func (*languageTranscriber) Dependencies(*Config) []Dependency { return nil }
+func (*languageTranscriber) CacheKey(*Config) []string         { return nil }
+
+func (transcriber *languageTranscriber) Transcribe(audioPath string, config *Config) (*WhisperOutput, error) {
+	transcriber.mu.Lock()
+	transcriber.languages[audioPath] = config.Language
+	transcriber.mu.Unlock()
+
+	if audioPath == "silent" {
+		return nil, ErrNoSegmentsFound
+	}
+	language := config.Language
+	if language == autoLanguage {
+		language = "ja"
+	}
+	return &WhisperOutput{Language: language, Segments: []Segment{{Text: audioPath}}}, nil
+}
+
+func TestTranscribeChunksPinsDetectedLanguage(t *testing.T) {
+	transcriber := &languageTranscriber{languages: map[string]string{}}
+	chunks := []audioChunk{{Path: "silent"}, {Path: "first"}, {Path: "second"}, {Path: "third"}}
+	config := &Config{Language: autoLanguage, Jobs: 2}
+
+	results, err := transcribeChunks(transcriber, chunks, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"silent": autoLanguage, "first": autoLanguage, "second": "ja", "third": "ja"}
+	for path, language := range want {
+		if got := transcriber.languages[path]; got != language {
+			t.Errorf("chunk %s transcribed with %q, want %q", path, got, language)
+		}
+	}
+	if results[0] != nil || results[1] == nil || results[3] == nil {
+		t.Errorf("results = %v", results)
+	}
+	if config.Language != autoLanguage {
+		t.Error("pinning modified the caller's config")
+	}
+}
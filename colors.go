@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 )
@@ -66,6 +68,10 @@ var (
 	BrandColor     = BrightRed
 )
 
+// uiWriter receives all progress output. It is stdout unless the transcript itself is streamed
+// there with -output=-, in which case progress moves to stderr.
+var uiWriter io.Writer = os.Stdout
+
 // gradientColors defines 256-color ANSI escape sequences for logo gradient effect.
 // Progresses from bright blue through cyan tones to light blue for visual appeal.
 var gradientColors = []string{
@@ -118,56 +124,56 @@ func logo() string {
 func spinner(message string, duration time.Duration) {
 	spinChars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
-	fmt.Print(prefix() + colorize(message, InfoColor) + " ")
+	fmt.Fprint(uiWriter, prefix()+colorize(message, InfoColor)+" ")
 
 	start := time.Now()
 	i := 0
 	for time.Since(start) < duration {
-		fmt.Printf("\r%s%s %s", prefix(), colorize(message, InfoColor), colorize(spinChars[i%len(spinChars)], PrimaryColor))
+		fmt.Fprintf(uiWriter, "\r%s%s %s", prefix(), colorize(message, InfoColor), colorize(spinChars[i%len(spinChars)], PrimaryColor))
 		time.Sleep(spinnerSleepDuration)
 		i++
 	}
-	fmt.Print("\r" + strings.Repeat(" ", len(message)+clearLinePadding) + "\r")
+	fmt.Fprint(uiWriter, "\r"+strings.Repeat(" ", len(message)+clearLinePadding)+"\r")
 }
 
 func success(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("✅", SuccessColor), colorize(message, SuccessColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("✅", SuccessColor), colorize(message, SuccessColor))
 }
 
 func warning(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("⚠️", WarningColor), colorize(message, WarningColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("⚠️", WarningColor), colorize(message, WarningColor))
 }
 
 func errorMsg(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("❌", ErrorColor), colorize(message, ErrorColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("❌", ErrorColor), colorize(message, ErrorColor))
 }
 
 func info(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("ℹ️", InfoColor), colorize(message, InfoColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("ℹ️", InfoColor), colorize(message, InfoColor))
 }
 
 func step(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("🔄", PrimaryColor), colorize(message, PrimaryColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("🔄", PrimaryColor), colorize(message, PrimaryColor))
 }
 
 func download(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("📥", SecondaryColor), colorize(message, SecondaryColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("📥", SecondaryColor), colorize(message, SecondaryColor))
 }
 
 func processing(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("🧠", InfoColor), colorize(message, InfoColor))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("🧠", InfoColor), colorize(message, InfoColor))
 }
 
 func file(message string) {
-	fmt.Printf("%s%s %s\n", prefix(), colorize("📄", MutedColor), colorize(message, White))
+	fmt.Fprintf(uiWriter, "%s%s %s\n", prefix(), colorize("📄", MutedColor), colorize(message, White))
 }
 
 func header(message string) {
-	fmt.Printf("\n%s%s\n", prefix(), colorize(bold(message), PrimaryColor))
+	fmt.Fprintf(uiWriter, "\n%s%s\n", prefix(), colorize(bold(message), PrimaryColor))
 }
 
 func subheader(message string) {
-	fmt.Printf("%s%s\n", prefix(), colorize(message, SecondaryColor))
+	fmt.Fprintf(uiWriter, "%s%s\n", prefix(), colorize(message, SecondaryColor))
 }
 
 func betterLyrics() string {
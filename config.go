@@ -4,18 +4,74 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 )
 
 // Config holds all command-line configuration options for EchoWave transcription.
 // Contains Whisper model settings, audio processing options, and output preferences.
 type Config struct {
+	Command     string
+	Backend     string
 	Model       string
+	ModelDir    string
 	Language    string
 	AudioFormat string
 	OutputDir   string
 	Output      string
 	Verbose     bool
+	Translate   bool
+	Romanize    bool
+	Task        string
 	Heatmap     bool
+	Formats     []string
+
+	ASSFont           string
+	ASSFontSize       int
+	ASSPrimaryColor   string
+	ASSSecondaryColor string
+	ASSOutlineColor   string
+	ASSPosition       string
+	ASSTint           bool
+
+	TTMLAgent string
+
+	WhisperCppBinary string
+
+	FasterWhisperBinary string
+	ComputeType         string
+
+	APIBaseURL string
+	APIKey     string
+
+	IsolateVocals bool
+	Separator     string
+	Normalize     bool
+	HighPass      int
+	LowPass       int
+
+	Jobs        int
+	ChunkLength float64
+
+	Start      float64
+	End        float64
+	Timestamps string
+
+	Lyrics string
+
+	Prompt string
+
+	Hallucinations string
+
+	Playlist bool
+
+	Recursive bool
+	Workers   int
+
+	Cache       bool
+	CacheDir    string
+	CacheSize   int
+	CacheAction string
 }
 
 // showHelp displays the complete help documentation for EchoWave including usage examples,
@@ -36,24 +92,104 @@ func showHelp() {
 	fmt.Printf("%s %s\n", colorize("🌐", InfoColor), colorize("Visit: ", InfoColor)+link("https://better-lyrics.boidu.dev"))
 	fmt.Println()
 
-	fmt.Print(box("Usage", "echowave [OPTIONS] <YouTube URL or path/to/audio>"))
+	fmt.Print(box("Usage", "echowave [OPTIONS] <URL, path/to/audio or - for stdin>\nechowave convert [OPTIONS] <whisper.json, lyrics.lrc or directory>...\nechowave cache [ls|prune|clear] [OPTIONS]"))
 	fmt.Println()
 
 	fmt.Printf("%s\n", colorize(bold("Options"), PrimaryColor))
+	fmt.Printf("%s\n", colorize("  -backend string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Transcription backend: whisper, whisper-cpp, faster-whisper, openai (default \"whisper\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -model string", PrimaryColor))
 	fmt.Printf("%s\n", colorize("        Whisper model to use (default \"large-v3\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -model-dir string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Directory containing ggml-<model>.bin files for whisper-cpp (default $WHISPER_CPP_MODEL_DIR or ./models)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -whisper-cpp-bin string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        whisper.cpp executable to run (default \"whisper-cli\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -faster-whisper-bin string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        faster-whisper executable to run (default \"whisper-ctranslate2\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -compute-type string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        CTranslate2 compute type for faster-whisper, e.g. int8, float16 (default \"auto\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -api-base-url string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Base URL of an OpenAI-compatible server for the openai backend (default $ECHOWAVE_API_BASE_URL, $OPENAI_BASE_URL or https://api.openai.com/v1)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -api-key string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        API key for the openai backend (default $ECHOWAVE_API_KEY or $OPENAI_API_KEY)", MutedColor))
 	fmt.Printf("%s\n", colorize("  -language string", PrimaryColor))
-	fmt.Printf("%s\n", colorize("        Language for transcription (default \"en\")", MutedColor))
+	fmt.Printf("%s\n", colorize("        Language for transcription, or \"auto\" to detect it (default \"en\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -audio-format string", PrimaryColor))
-	fmt.Printf("%s\n", colorize("        Audio format for download (default \"mp3\")", MutedColor))
+	fmt.Printf("%s\n", colorize("        Audio format for yt-dlp downloads (default \"mp3\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -output-dir string", PrimaryColor))
 	fmt.Printf("%s\n", colorize("        Output directory for generated files (default \".\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -output string", PrimaryColor))
-	fmt.Printf("%s\n", colorize("        Output file path (without extension)", MutedColor))
+	fmt.Printf("%s\n", colorize("        Output file path (without extension); {lang} is replaced by the transcription language; - writes the single -format to stdout", MutedColor))
+	fmt.Printf("%s\n", colorize("  -translate", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Also translate to English and write bilingual and translated-only LRC files", MutedColor))
+	fmt.Printf("%s\n", colorize("  -romanize", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Add romanized lines for ja, ko, zh and ru lyrics to LRC and TTML output", MutedColor))
+	fmt.Printf("%s\n", colorize("  -isolate-vocals", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Transcribe the vocal stem extracted by a source-separation tool", MutedColor))
+	fmt.Printf("%s\n", colorize("  -separator string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Vocal separator for -isolate-vocals: demucs, audio-separator (default \"demucs\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -normalize", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Convert input to loudness-normalized 16 kHz mono WAV with ffmpeg first (default false)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -highpass int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        High-pass filter cutoff in Hz applied during normalization; turns on -normalize (0 disables)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -lowpass int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Low-pass filter cutoff in Hz applied during normalization; turns on -normalize (0 disables)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -jobs int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Number of chunks transcribed in parallel; above 1 enables chunking (default 1)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -chunk-length float", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Split long audio at silences into chunks of about this many seconds (default 300 with -jobs)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -playlist", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Transcribe every entry of the playlist in the URL (automatic for playlist and channel pages)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -recursive", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Also walk subdirectories of directory inputs, mirroring the tree under -output-dir", MutedColor))
+	fmt.Printf("%s\n", colorize("  -workers int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Number of files transcribed at once when given several files, directories or globs (default 1)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -cache", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Reuse cached transcriptions of identical audio and options (default true)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -cache-dir string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Directory for cached transcriptions (default $ECHOWAVE_CACHE_DIR or echowave in the user cache directory)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -cache-size int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Cache size limit in MB; least recently used entries are evicted (default 500)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -lyrics string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Plain-text lyrics file to time against the audio; output text is exactly the file's lines", MutedColor))
+	fmt.Printf("%s\n", colorize("  -prompt string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Initial prompt with known lyrics or context to bias transcription", MutedColor))
+	fmt.Printf("%s\n", colorize("  -prompt-file string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        File whose text is added to the initial prompt", MutedColor))
+	fmt.Printf("%s\n", colorize("  -vocabulary string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        File of artist names, slang and other terms to spell correctly, one per line", MutedColor))
+	fmt.Printf("%s\n", colorize("  -hallucinations string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Drop, mark or keep (off) repeated and invented lines such as \"Thanks for watching\" (default \"mark\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -start string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Transcribe from this time: seconds, mm:ss, hh:mm:ss or 1m30s (defaults to a URL's t= parameter)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -end string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Transcribe up to this time (default end of track)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -timestamps string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Timestamps for a -start/-end range: original (track time) or clip (from 0) (default \"original\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -verbose", PrimaryColor))
 	fmt.Printf("%s\n", colorize("        Show detailed output from tools", MutedColor))
 	fmt.Printf("%s\n", colorize("  -heatmap", PrimaryColor))
 	fmt.Printf("%s\n", colorize("        Show transcription accuracy heatmap (default true)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -format string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Comma-separated output formats: lrc, elrc, srt, vtt, ass, ttml, bilingual, translated, romanized (default \"lrc\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -enhanced-lrc", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Also write a word-timed enhanced LRC file (same as adding elrc to -format)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-font string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Font name for ASS karaoke subtitles (default \"Arial\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-font-size int", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Font size for ASS karaoke subtitles (default 64)", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-primary-color string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Colour of sung words in ASS subtitles (default \"#FFFFFF\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-secondary-color string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Colour of upcoming words in ASS subtitles (default \"#00FFFF\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-outline-color string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Outline colour in ASS subtitles (default \"#000000\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-position string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Vertical position of ASS subtitles: bottom, middle, top (default \"bottom\")", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ass-tint", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Tint low-confidence words in ASS subtitles using heatmap colours", MutedColor))
+	fmt.Printf("%s\n", colorize("  -ttml-agent string", PrimaryColor))
+	fmt.Printf("%s\n", colorize("        Vocalist id written as ttm:agent on every TTML line (e.g. \"v1\")", MutedColor))
 	fmt.Printf("%s\n", colorize("  -help", PrimaryColor))
 	fmt.Printf("%s\n", colorize("        Show this help message", MutedColor))
 	fmt.Printf("%s\n", colorize("  -version", PrimaryColor))
@@ -70,6 +206,54 @@ func showHelp() {
 	fmt.Printf("%s\n", colorize("# Custom model and language", SecondaryColor))
 	fmt.Printf("%s\n", colorize("echowave -model=medium -language=es -output=transcript audio.mp3", White))
 	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Fast CPU transcription with whisper.cpp", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -backend=whisper-cpp -model=base.en -model-dir=~/whisper.cpp/models audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# int8 CPU inference with faster-whisper", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -backend=faster-whisper -model=distil-large-v3 -compute-type=int8 audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Self-hosted OpenAI-compatible transcription server", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -backend=openai -api-base-url=http://localhost:8000/v1 audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Detect the language and name files after it", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -language=auto -output=song.{lang} audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Bilingual lyrics with an English translation", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -language=ja -translate audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Korean lyrics with romanization in LRC and TTML", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -language=ko -romanize -format=lrc,ttml audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Transcribe the vocal stem of a dense mix", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -isolate-vocals audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Cut rumble and hiss from a live recording before transcription", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -highpass=100 -lowpass=7000 concert.mp4", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Transcribe a long DJ set in 4 parallel chunks", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -jobs=4 dj-set.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Time lyrics you already have instead of using Whisper's text", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -lyrics=lyrics.txt audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Bias Whisper with the chorus you know and the artist's spelling", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -prompt-file=chorus.txt -vocabulary=names.txt audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Transcribe only the second verse, with timestamps counted from the clip", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -start=1:05 -end=1:50 -timestamps=clip audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Transcribe a music library, two files at a time, mirroring its folders", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -recursive -workers=2 -output-dir=lyrics ~/Music", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Transcribe a whole album playlist into albums/<playlist title>/", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -output-dir=albums \"https://youtube.com/playlist?list=xyz\"", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Any site yt-dlp supports, such as SoundCloud or Bandcamp", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave https://soundcloud.com/artist/track", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Pipe audio in and lyrics out", SecondaryColor))
+	fmt.Printf("%s\n", colorize("ffmpeg -i song.flac -f wav - | echowave -output=- - > song.lrc", White))
+	fmt.Println()
 	fmt.Printf("%s\n", colorize("# Custom output directory", SecondaryColor))
 	fmt.Printf("%s\n", colorize("echowave -output-dir=transcripts https://youtube.com/watch?v=xyz", White))
 	fmt.Println()
@@ -79,6 +263,27 @@ func showHelp() {
 	fmt.Printf("%s\n", colorize("# Disable accuracy heatmap", SecondaryColor))
 	fmt.Printf("%s\n", colorize("echowave -heatmap=false audio.mp3", White))
 	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Word-level karaoke lyrics", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -enhanced-lrc audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Subtitles for video", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -format=lrc,srt,vtt audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Karaoke lyric video subtitles for Aegisub", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -format=ass -ass-font=\"Noto Sans\" -ass-tint audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Word-timed TTML lyrics", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave -format=lrc,ttml audio.mp3", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Re-render an existing Whisper JSON without re-transcribing", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave convert -format=srt,ttml transcript.json", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Convert every Whisper JSON in a directory", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave convert -format=lrc,elrc -output-dir=lyrics transcripts/", White))
+	fmt.Println()
+	fmt.Printf("%s\n", colorize("# Shrink the transcription cache to 100 MB", SecondaryColor))
+	fmt.Printf("%s\n", colorize("echowave cache prune -cache-size=100", White))
+	fmt.Println()
 	fmt.Printf("%s\n", colorize("# Show version", SecondaryColor))
 	fmt.Printf("%s\n", colorize("echowave -version", White))
 	fmt.Println()
@@ -98,15 +303,69 @@ func showHelp() {
 // returning the configuration object.
 func parseFlags() *Config {
 	var (
+		backend     = flag.String("backend", "whisper", "Transcription backend (whisper, whisper-cpp, faster-whisper, openai)")
 		model       = flag.String("model", "medium", "Whisper model to use")
-		language    = flag.String("language", "en", "Language for transcription")
-		audioFormat = flag.String("audio-format", "mp3", "Audio format for download")
+		modelDir    = flag.String("model-dir", "", "Directory containing ggml model files for whisper-cpp")
+		language    = flag.String("language", "en", "Language for transcription (or auto)")
+		audioFormat = flag.String("audio-format", "mp3", "Audio format for yt-dlp downloads")
 		outputDir   = flag.String("output-dir", ".", "Output directory for generated files")
 		output      = flag.String("output", "", "Output file path (without extension)")
 		verbose     = flag.Bool("verbose", false, "Show detailed output from tools")
+		translate   = flag.Bool("translate", false, "Also translate to English and write bilingual LRC")
+		romanize    = flag.Bool("romanize", false, "Add romanized lines for ja, ko, zh and ru lyrics")
 		heatmap     = flag.Bool("heatmap", true, "Show transcription accuracy heatmap")
+		format      = flag.String("format", "lrc", "Comma-separated output formats (lrc, elrc, srt, vtt, ass, ttml, bilingual, translated, romanized)")
+		enhancedLRC = flag.Bool("enhanced-lrc", false, "Also write a word-timed enhanced LRC file")
 		help        = flag.Bool("help", false, "Show help message")
 		version     = flag.Bool("version", false, "Show version information")
+
+		assFont           = flag.String("ass-font", "Arial", "Font name for ASS karaoke subtitles")
+		assFontSize       = flag.Int("ass-font-size", 64, "Font size for ASS karaoke subtitles")
+		assPrimaryColor   = flag.String("ass-primary-color", "#FFFFFF", "Colour of sung words in ASS subtitles")
+		assSecondaryColor = flag.String("ass-secondary-color", "#00FFFF", "Colour of upcoming words in ASS subtitles")
+		assOutlineColor   = flag.String("ass-outline-color", "#000000", "Outline colour in ASS subtitles")
+		assPosition       = flag.String("ass-position", "bottom", "Vertical position of ASS subtitles (bottom, middle, top)")
+		assTint           = flag.Bool("ass-tint", false, "Tint low-confidence words in ASS subtitles")
+
+		ttmlAgent = flag.String("ttml-agent", "", "Vocalist id written as ttm:agent on every TTML line")
+
+		whisperCppBinary = flag.String("whisper-cpp-bin", "", "whisper.cpp executable to run")
+
+		fasterWhisperBinary = flag.String("faster-whisper-bin", "", "faster-whisper executable to run")
+		computeType         = flag.String("compute-type", "auto", "CTranslate2 compute type for faster-whisper")
+
+		apiBaseURL = flag.String("api-base-url", "", "Base URL of an OpenAI-compatible transcription server")
+		apiKey     = flag.String("api-key", "", "API key for the openai backend")
+
+		isolateVocals = flag.Bool("isolate-vocals", false, "Transcribe the vocal stem extracted by a source-separation tool")
+		separator     = flag.String("separator", "demucs", "Vocal separator for -isolate-vocals (demucs, audio-separator)")
+		normalize     = flag.Bool("normalize", false, "Convert input to loudness-normalized 16 kHz mono WAV before transcription")
+		highPass      = flag.Int("highpass", 0, "High-pass filter cutoff in Hz applied during normalization (0 disables)")
+		lowPass       = flag.Int("lowpass", 0, "Low-pass filter cutoff in Hz applied during normalization (0 disables)")
+
+		jobs        = flag.Int("jobs", 1, "Number of chunks transcribed in parallel")
+		chunkLength = flag.Float64("chunk-length", 0, "Split long audio at silences into chunks of about this many seconds")
+
+		start      = flag.String("start", "", "Transcribe from this time (seconds, mm:ss, hh:mm:ss or 1m30s)")
+		end        = flag.String("end", "", "Transcribe up to this time (seconds, mm:ss, hh:mm:ss or 1m30s)")
+		timestamps = flag.String("timestamps", timestampsOriginal, "Timestamps relative to the original track or the clip (original, clip)")
+
+		lyrics = flag.String("lyrics", "", "Plain-text lyrics file to time against the audio instead of using the transcribed text")
+
+		prompt         = flag.String("prompt", "", "Initial prompt with known lyrics or context to bias transcription")
+		promptFile     = flag.String("prompt-file", "", "File whose text is added to the initial prompt")
+		vocabularyFile = flag.String("vocabulary", "", "File of names and terms, one per line, to spell correctly")
+
+		hallucinations = flag.String("hallucinations", defaultHallucinationMode, "Handling of repeated and invented lines (drop, mark, off)")
+
+		playlist = flag.Bool("playlist", false, "Transcribe every entry of the playlist in the URL instead of a single video")
+
+		recursive = flag.Bool("recursive", false, "Also walk subdirectories of directory inputs")
+		workers   = flag.Int("workers", 1, "Number of files transcribed at once in batch mode")
+
+		cache          = flag.Bool("cache", true, "Reuse cached transcriptions of identical audio and options")
+		cacheDirectory = flag.String("cache-dir", "", "Directory for cached transcriptions")
+		cacheSize      = flag.Int("cache-size", 500, "Cache size limit in MB; least recently used entries are evicted")
 	)
 	flag.Parse()
 
@@ -123,17 +382,185 @@ func parseFlags() *Config {
 		os.Exit(0)
 	}
 
-	if flag.NArg() < 1 {
+	command := ""
+	if flag.NArg() >= 1 && flag.Arg(0) == "convert" {
+		command = "convert"
+		// Allow options after the subcommand, e.g. "echowave convert -format=srt song.json".
+		_ = flag.CommandLine.Parse(flag.Args()[1:])
+		if flag.NArg() < 1 {
+			exitWithError(newError("convert transcriptions", ErrNoConvertInputs))
+		}
+	}
+
+	cacheAction := ""
+	if flag.NArg() >= 1 && flag.Arg(0) == "cache" {
+		command = "cache"
+		// Options may come before or after the action, e.g. "echowave cache prune -cache-size=100".
+		_ = flag.CommandLine.Parse(flag.Args()[1:])
+		cacheAction = flag.Arg(0)
+		if flag.NArg() >= 1 {
+			_ = flag.CommandLine.Parse(flag.Args()[1:])
+		}
+	}
+
+	if flag.NArg() < 1 && command != "cache" {
 		showHelp()
 	}
 
-	return &Config{
+	streaming := *output == stdioPath
+	if streaming {
+		// stdout carries the transcript itself, so progress goes to stderr.
+		uiWriter = os.Stderr
+	}
+
+	if _, err := findTranscriber(*backend); err != nil {
+		exitWithError(newError("select transcription backend", err))
+	}
+
+	if *isolateVocals {
+		if _, err := findVocalSeparator(*separator); err != nil {
+			exitWithError(newError("select vocal separator", err))
+		}
+	}
+
+	if err := validateFilterFrequencies(*highPass, *lowPass); err != nil {
+		exitWithError(newError("validate audio filters", err))
+	}
+
+	if err := validateChunking(*jobs, *chunkLength); err != nil {
+		exitWithError(newError("validate chunking", err))
+	}
+
+	var startSeconds, endSeconds float64
+	if command == "" {
+		var err error
+		if startSeconds, endSeconds, err = parseTimeRange(*start, *end); err != nil {
+			exitWithError(newError("parse time range", err))
+		}
+		if err := validateTimestampsMode(*timestamps); err != nil {
+			exitWithError(newError("parse time range", err))
+		}
+	}
+
+	if err := validateWorkers(*workers); err != nil {
+		exitWithError(newError("validate batch options", err))
+	}
+
+	if err := validateCacheSize(*cacheSize); err != nil {
+		exitWithError(newError("validate cache options", err))
+	}
+
+	if err := validateHallucinationMode(*hallucinations); err != nil {
+		exitWithError(newError("parse hallucination mode", err))
+	}
+
+	initialPrompt, err := buildPrompt(*prompt, *promptFile, *vocabularyFile)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	languageCode, err := parseLanguageOption(*language)
+	if err != nil {
+		exitWithError(newError("parse language", err))
+	}
+
+	formats, err := parseOutputFormats(*format)
+	if err != nil {
+		exitWithError(newError("parse output formats", err))
+	}
+	if streaming {
+		// A stream holds one format, so the options below do not add theirs; -format picks it.
+		if err := validateStreamFormats(formats); err != nil {
+			exitWithError(newError("parse output formats", err))
+		}
+		enabled := map[string]bool{"-enhanced-lrc": *enhancedLRC, "-romanize": *romanize, "-translate": *translate && command != "convert"}
+		if err := validateStreamOptions(formats[0], enabled); err != nil {
+			exitWithError(newError("parse output formats", err))
+		}
+	}
+	if *enhancedLRC && !streaming && !slices.Contains(formats, "elrc") {
+		formats = append(formats, "elrc")
+	}
+	if *romanize && !streaming && !slices.Contains(formats, "romanized") {
+		formats = append(formats, "romanized")
+	}
+	if *translate && !streaming && command != "convert" {
+		for _, name := range []string{"bilingual", "translated"} {
+			if !slices.Contains(formats, name) {
+				formats = append(formats, name)
+			}
+		}
+	}
+
+	config := &Config{
+		Command:     command,
+		Backend:     *backend,
 		Model:       *model,
-		Language:    *language,
+		ModelDir:    *modelDir,
+		Language:    languageCode,
 		AudioFormat: *audioFormat,
 		OutputDir:   *outputDir,
 		Output:      *output,
 		Verbose:     *verbose,
+		Translate:   *translate,
+		Romanize:    *romanize,
+		Task:        taskTranscribe,
 		Heatmap:     *heatmap,
+		Formats:     formats,
+
+		ASSFont:           *assFont,
+		ASSFontSize:       *assFontSize,
+		ASSPrimaryColor:   *assPrimaryColor,
+		ASSSecondaryColor: *assSecondaryColor,
+		ASSOutlineColor:   *assOutlineColor,
+		ASSPosition:       strings.ToLower(strings.TrimSpace(*assPosition)),
+		ASSTint:           *assTint,
+
+		TTMLAgent: strings.TrimSpace(*ttmlAgent),
+
+		WhisperCppBinary: *whisperCppBinary,
+
+		FasterWhisperBinary: *fasterWhisperBinary,
+		ComputeType:         strings.ToLower(strings.TrimSpace(*computeType)),
+
+		APIBaseURL: strings.TrimSpace(*apiBaseURL),
+		APIKey:     *apiKey,
+
+		IsolateVocals: *isolateVocals,
+		Separator:     *separator,
+		Normalize:     *normalize || *highPass > 0 || *lowPass > 0,
+		HighPass:      *highPass,
+		LowPass:       *lowPass,
+
+		Jobs:        *jobs,
+		ChunkLength: *chunkLength,
+
+		Start:      startSeconds,
+		End:        endSeconds,
+		Timestamps: *timestamps,
+
+		Lyrics: strings.TrimSpace(*lyrics),
+
+		Prompt: initialPrompt,
+
+		Hallucinations: *hallucinations,
+
+		Playlist: *playlist,
+
+		Recursive: *recursive,
+		Workers:   *workers,
+
+		Cache:       *cache,
+		CacheDir:    strings.TrimSpace(*cacheDirectory),
+		CacheSize:   *cacheSize,
+		CacheAction: cacheAction,
 	}
+
+	if slices.Contains(formats, "ass") {
+		if err := validateASSStyle(config); err != nil {
+			exitWithError(newError("validate ASS style", err))
+		}
+	}
+
+	return config
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoConvertInputs    = errors.New("no Whisper JSON or LRC files found")
+	ErrOutputWithMultiple = errors.New("-output cannot be used with multiple inputs")
+)
+
+// collectConvertInputs expands the convert arguments into a list of Whisper JSON and LRC files.
+// Files are used as given; directories contribute their top-level .json and .lrc files in sorted order,
+// except LRC files that convert renders from a JSON beside them, so a directory can be converted again.
+func collectConvertInputs(paths []string) ([]string, error) {
+	var inputs []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, newError("read convert input", err)
+		}
+
+		if !info.IsDir() {
+			inputs = append(inputs, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, newError("read convert directory", err)
+		}
+
+		var found []string
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || (ext != ".json" && ext != ".lrc") {
+				continue
+			}
+			if ext == ".lrc" && isRenderedFromJSON(path, entry.Name()) {
+				continue
+			}
+			found = append(found, filepath.Join(path, entry.Name()))
+		}
+		sort.Strings(found)
+		inputs = append(inputs, found...)
+	}
+
+	if len(inputs) == 0 {
+		return nil, newError("collect convert inputs", ErrNoConvertInputs)
+	}
+
+	return inputs, nil
+}
+
+// isRenderedFromJSON reports whether the LRC file name in dir is one of the files convert writes for a
+// Whisper JSON in the same directory, such as song.lrc or song.romanized.lrc next to song.json.
+func isRenderedFromJSON(dir, name string) bool {
+	for _, format := range outputFormats {
+		base, ok := strings.CutSuffix(name, format.Extension)
+		if !ok || base == "" {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(dir, base+".json")); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// runConvert re-renders existing Whisper JSON or LRC transcriptions into the selected output formats
+// without downloading audio or running Whisper. Each input is converted independently so one
+// malformed file does not stop the rest; an error is returned if any conversion failed.
+func runConvert(paths []string, config *Config) error {
+	inputs, err := collectConvertInputs(paths)
+	if err != nil {
+		return err
+	}
+
+	if config.Output != "" && len(inputs) > 1 {
+		return newError("convert transcriptions", ErrOutputWithMultiple)
+	}
+
+	step("Setting up output directory...")
+	if err := os.MkdirAll(config.OutputDir, 0o750); err != nil {
+		return newError("create output directory", err)
+	}
+
+	failed := 0
+	for _, inputPath := range inputs {
+		subheader("Converting " + inputPath)
+
+		name := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
+		if config.Output != "" {
+			name = config.Output
+		}
+
+		if isStdoutOutput(config) {
+			err = streamOutputFormat(inputPath, config)
+		} else {
+			err = renderOutputFormats(inputPath, filepath.Join(config.OutputDir, name), inputs, config)
+		}
+		if err != nil {
+			errorMsg(err.Error())
+			failed++
+			continue
+		}
+
+		if config.Heatmap {
+			fmt.Fprintln(uiWriter)
+			if err := displayHeatmap(inputPath); err != nil {
+				warning("Failed to display heatmap: " + err.Error())
+			}
+		}
+	}
+
+	fmt.Fprintln(uiWriter)
+	if failed > 0 {
+		return newError("convert transcriptions", fmt.Errorf("%d of %d files failed", failed, len(inputs)))
+	}
+
+	success(fmt.Sprintf("Converted %d transcription(s) successfully!", len(inputs)))
+	info("Files saved in: " + config.OutputDir)
+	return nil
+}
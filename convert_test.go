@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRenderOutputFormatsRefusesToOverwriteOtherInputs(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "song.json")
+	lrcPath := filepath.Join(dir, "song.lrc")
+	const handEdited = "[00:01.00]Hand-edited line\n"
+	if err := os.WriteFile(jsonPath, []byte(whisperDocument), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lrcPath, []byte(handEdited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both files given explicitly, as in `echowave convert -format=srt,lrc song.json song.lrc`.
+	inputs, err := collectConvertInputs([]string{jsonPath, lrcPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &Config{Formats: []string{"srt", "lrc"}, OutputDir: dir}
+
+	err = renderOutputFormats(jsonPath, filepath.Join(dir, "song"), inputs, config)
+	if !errors.Is(err, ErrOverwriteInput) {
+		t.Fatalf("err = %v, want %v", err, ErrOverwriteInput)
+	}
+	if data, _ := os.ReadFile(lrcPath); string(data) != handEdited {
+		t.Errorf("song.lrc was overwritten with %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "song.srt")); !os.IsNotExist(err) {
+		t.Error("formats were written before the overwrite was detected")
+	}
+
+	if err := renderOutputFormats(jsonPath, filepath.Join(dir, "out"), inputs, config); err != nil {
+		t.Fatalf("rendering to a free name: %v", err)
+	}
+}
+
+func TestConvertDirectoryTwice(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "song.json"), []byte(whisperDocument), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second run finds song.lrc and song.enhanced.lrc from the first and must treat them as outputs.
+	for run := 1; run <= 2; run++ {
+		config := &Config{Command: "convert", Formats: []string{"lrc", "elrc", "srt"}, OutputDir: dir}
+		if err := runConvert([]string{dir}, config); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "other.lrc"), []byte("[00:01.00]Only an LRC\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inputs, err := collectConvertInputs([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "other.lrc"), filepath.Join(dir, "song.json")}
+	if !slices.Equal(inputs, want) {
+		t.Errorf("inputs = %q, want %q", inputs, want)
+	}
+}
@@ -15,6 +15,7 @@ type Dependency struct {
 	InstallDocs map[string]string
 }
 
+// dependencies lists the tools required regardless of the transcription backend.
 var dependencies = []Dependency{
 	{
 		Name:    "ffmpeg",
@@ -25,15 +26,6 @@ var dependencies = []Dependency{
 			"windows": "Download from https://ffmpeg.org/download.html",
 		},
 	},
-	{
-		Name:    "openai-whisper",
-		Command: "whisper",
-		InstallDocs: map[string]string{
-			"darwin":  "pip install openai-whisper",
-			"linux":   "pip install openai-whisper",
-			"windows": "pip install openai-whisper",
-		},
-	},
 	{
 		Name:    "yt-dlp",
 		Command: "yt-dlp",
@@ -45,6 +37,71 @@ var dependencies = []Dependency{
 	},
 }
 
+var whisperDependency = Dependency{
+	Name:    "openai-whisper",
+	Command: "whisper",
+	InstallDocs: map[string]string{
+		"darwin":  "pip install openai-whisper",
+		"linux":   "pip install openai-whisper",
+		"windows": "pip install openai-whisper",
+	},
+}
+
+var whisperCppDependency = Dependency{
+	Name:    "whisper.cpp",
+	Command: "whisper-cli",
+	InstallDocs: map[string]string{
+		"darwin":  "brew install whisper-cpp\n# Download a model into ./models or $WHISPER_CPP_MODEL_DIR, e.g. ggml-medium.bin",
+		"linux":   "# Build from https://github.com/ggml-org/whisper.cpp and put whisper-cli on your PATH\n# Download a model into ./models or $WHISPER_CPP_MODEL_DIR, e.g. ggml-medium.bin",
+		"windows": "# Download a release from https://github.com/ggml-org/whisper.cpp/releases\n# Download a model into ./models or $WHISPER_CPP_MODEL_DIR, e.g. ggml-medium.bin",
+	},
+}
+
+var fasterWhisperDependency = Dependency{
+	Name:    "faster-whisper",
+	Command: "whisper-ctranslate2",
+	InstallDocs: map[string]string{
+		"darwin":  "pip install whisper-ctranslate2",
+		"linux":   "pip install whisper-ctranslate2",
+		"windows": "pip install whisper-ctranslate2",
+	},
+}
+
+var demucsDependency = Dependency{
+	Name:    "demucs",
+	Command: "demucs",
+	InstallDocs: map[string]string{
+		"darwin":  "pip install demucs",
+		"linux":   "pip install demucs",
+		"windows": "pip install demucs",
+	},
+}
+
+var audioSeparatorDependency = Dependency{
+	Name:    "audio-separator",
+	Command: "audio-separator",
+	InstallDocs: map[string]string{
+		"darwin":  "pip install \"audio-separator[cpu]\"",
+		"linux":   "pip install \"audio-separator[cpu]\"  # or audio-separator[gpu] with CUDA",
+		"windows": "pip install \"audio-separator[cpu]\"",
+	},
+}
+
+// requiredDependencies returns the common dependencies plus those needed by the selected backend
+// and, when -isolate-vocals is set, the selected vocal separator.
+func requiredDependencies(transcriber Transcriber, config *Config) []Dependency {
+	backendDependencies := transcriber.Dependencies(config)
+	required := make([]Dependency, 0, len(dependencies)+len(backendDependencies)+1)
+	required = append(required, dependencies...)
+	required = append(required, backendDependencies...)
+	if config.IsolateVocals {
+		if separator, err := findVocalSeparator(config.Separator); err == nil {
+			required = append(required, separator.Dependency)
+		}
+	}
+	return required
+}
+
 // checkDependency verifies if a specific dependency is installed and available in the system PATH.
 // It takes a Dependency struct and attempts to locate the corresponding command using exec.LookPath.
 // Returns true if the dependency is found and executable, false otherwise. This function is used
@@ -66,30 +123,30 @@ func showInstallInstructions(dep Dependency) {
 		lines := strings.Split(instructions, "\n")
 		for _, line := range lines {
 			if strings.HasPrefix(line, "#") {
-				fmt.Printf("%s%s\n", prefix(), colorize(line, SecondaryColor))
+				fmt.Fprintf(uiWriter, "%s%s\n", prefix(), colorize(line, SecondaryColor))
 			} else {
-				fmt.Printf("%s%s\n", prefix(), colorize(line, White))
+				fmt.Fprintf(uiWriter, "%s%s\n", prefix(), colorize(line, White))
 			}
 		}
 	} else {
-		fmt.Printf("%s%s\n", prefix(), colorize("Please install "+dep.Name+" for your operating system", MutedColor))
+		fmt.Fprintf(uiWriter, "%s%s\n", prefix(), colorize("Please install "+dep.Name+" for your operating system", MutedColor))
 	}
-	fmt.Println()
+	fmt.Fprintln(uiWriter)
 }
 
 // checkAllDependencies validates that all required external tools are installed and accessible.
-// It iterates through the global dependencies slice, checking each one using checkDependency.
+// It iterates through the given dependencies slice, checking each one using checkDependency.
 // For each dependency, it prints a success or error message with appropriate formatting.
 // If any dependencies are missing, it displays comprehensive installation instructions for
 // the current platform and returns false. Returns true only if all dependencies are satisfied,
 // allowing the main program to proceed with audio processing operations.
-func checkAllDependencies() bool {
+func checkAllDependencies(deps []Dependency) bool {
 	step("Checking dependencies...")
 
 	allPresent := true
 	var missing []Dependency
 
-	for _, dep := range dependencies {
+	for _, dep := range deps {
 		if checkDependency(dep) {
 			success(dep.Name + " found")
 		} else {
@@ -100,14 +157,14 @@ func checkAllDependencies() bool {
 	}
 
 	if !allPresent {
-		fmt.Println()
+		fmt.Fprintln(uiWriter)
 		warning("Missing dependencies: " + strings.Join(getMissingNames(missing), ", "))
-		fmt.Println()
+		fmt.Fprintln(uiWriter)
 		header("Installation Instructions")
 		for _, dep := range missing {
 			showInstallInstructions(dep)
 		}
-		fmt.Println()
+		fmt.Fprintln(uiWriter)
 		info("After installing the missing dependencies, please run the command again.")
 		return false
 	}
@@ -20,6 +20,11 @@ func (e *EchoWaveError) Error() string {
 	return fmt.Sprintf("%s failed: %v", e.Operation, e.Err)
 }
 
+// Unwrap returns the underlying error so errors.Is can match the sentinel errors EchoWave wraps.
+func (e *EchoWaveError) Unwrap() error {
+	return e.Err
+}
+
 // newError creates a new EchoWaveError instance that wraps an underlying error
 // with contextual information about the operation that failed. The operation parameter
 // should describe what was being attempted when the error occurred, while err contains
@@ -37,6 +42,6 @@ func newError(operation string, err error) *EchoWaveError {
 // a consistent way to handle fatal errors throughout the application by displaying
 // user-friendly error messages before graceful shutdown.
 func exitWithError(err error) {
-	fmt.Printf("❌ %v\n", err)
+	fmt.Fprintf(uiWriter, "❌ %v\n", err)
 	os.Exit(1)
 }
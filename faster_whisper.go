@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnsupportedComputeType = errors.New("unsupported compute type")
+
+// fasterWhisperModels lists the model names accepted by faster-whisper in addition to local model
+// directories. It includes the English-only and distilled variants that openai-whisper does not ship.
+var fasterWhisperModels = []string{
+	"tiny", "tiny.en", "base", "base.en", "small", "small.en", "medium", "medium.en",
+	"large", "large-v1", "large-v2", "large-v3", "large-v3-turbo", "turbo",
+	"distil-small.en", "distil-medium.en", "distil-large-v2", "distil-large-v3",
+}
+
+// computeTypes lists the CTranslate2 quantization types accepted by -compute-type.
+var computeTypes = []string{
+	"default", "auto", "int8", "int8_float16", "int8_float32", "int8_bfloat16",
+	"int16", "float16", "bfloat16", "float32",
+}
+
+// validateFasterWhisperModel checks if the model is a known faster-whisper model name
+// or a directory containing a converted CTranslate2 model.
+func validateFasterWhisperModel(model string) bool {
+	for _, allowed := range fasterWhisperModels {
+		if model == allowed {
+			return true
+		}
+	}
+	info, err := os.Stat(model)
+	return err == nil && info.IsDir()
+}
+
+// validateComputeType checks if the compute type is one CTranslate2 understands.
+func validateComputeType(computeType string) bool {
+	for _, allowed := range computeTypes {
+		if computeType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// fasterWhisperTranscriber drives the whisper-ctranslate2 CLI (or a compatible faster-whisper build
+// selected with -faster-whisper-bin), which accepts openai-whisper style arguments and writes the same JSON.
+type fasterWhisperTranscriber struct{}
+
+func (fasterWhisperTranscriber) Name() string {
+	return "faster-whisper"
+}
+
+func (fasterWhisperTranscriber) Dependencies(config *Config) []Dependency {
+	return []Dependency{fasterWhisperBinaryDependency(config)}
+}
+
+// CacheKey identifies the faster-whisper executable and the CTranslate2 compute type.
+func (fasterWhisperTranscriber) CacheKey(config *Config) []string {
+	return []string{commandIdentity(fasterWhisperBinaryDependency(config).Command), config.ComputeType}
+}
+
+// fasterWhisperBinaryDependency returns the faster-whisper dependency, honoring -faster-whisper-bin.
+func fasterWhisperBinaryDependency(config *Config) Dependency {
+	dep := fasterWhisperDependency
+	if config.FasterWhisperBinary != "" {
+		dep.Command = config.FasterWhisperBinary
+	}
+	return dep
+}
+
+// Transcribe runs faster-whisper into a temporary directory with word timestamps and loads its JSON.
+// Words and avg_logprob use the same JSON layout as openai-whisper, so they map directly onto Segment and Word.
+func (fasterWhisperTranscriber) Transcribe(audioPath string, config *Config) (*WhisperOutput, error) {
+	processing("Running faster-whisper transcription...")
+
+	if !validateFasterWhisperModel(config.Model) {
+		return nil, newError("validate faster-whisper model", fmt.Errorf("%w: %s", ErrUnsupportedWhisperModel, config.Model))
+	}
+	if !validateComputeType(config.ComputeType) {
+		return nil, newError("validate compute type", fmt.Errorf("%w: %s", ErrUnsupportedComputeType, config.ComputeType))
+	}
+
+	step("Model: " + config.Model + ", Language: " + config.Language + ", Compute type: " + config.ComputeType)
+
+	tmpDir, err := os.MkdirTemp("", "echowave-faster-whisper-*")
+	if err != nil {
+		return nil, newError("create temp directory", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := []string{audioPath, "--model", config.Model}
+	if config.Language != autoLanguage {
+		args = append(args, "--language", config.Language)
+	}
+	if config.Task == taskTranslate {
+		args = append(args, "--task", taskTranslate)
+	}
+	if config.Prompt != "" {
+		args = append(args, "--initial_prompt", config.Prompt)
+	}
+	args = append(args, "--compute_type", config.ComputeType, "--output_format", "json", "--word_timestamps", "True",
+		"--temperature", "0", "--output_dir", tmpDir)
+
+	cmd := exec.Command(fasterWhisperBinaryDependency(config).Command, args...)
+
+	var logs bytes.Buffer
+	cmd.Stdout = io.MultiWriter(uiWriter, &logs)
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, newError("run faster-whisper transcription", err)
+	}
+
+	audioBaseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
+	output, err := loadWhisperOutput(filepath.Join(tmpDir, audioBaseName+".json"))
+	if err != nil {
+		return nil, err
+	}
+	if language, probability := parseFasterWhisperDetectedLanguage(logs.String()); language != "" {
+		output.Language = language
+		output.LanguageProbability = probability
+	}
+
+	success("faster-whisper transcription completed")
+	return output, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrUnsupportedOutputFormat = errors.New("unsupported output format")
+	ErrOverwriteInput          = errors.New("output would overwrite the input file")
+)
+
+// OutputFormat describes a lyrics or subtitle format EchoWave can render from a WhisperOutput.
+// Contains the format name used on the command line, output file extension, and writer function.
+type OutputFormat struct {
+	Name      string
+	Label     string
+	Extension string
+	Write     func(w io.Writer, output *WhisperOutput, config *Config) error
+}
+
+var outputFormats = []OutputFormat{
+	{
+		Name:      "lrc",
+		Label:     "LRC",
+		Extension: ".lrc",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeLRC(w, output)
+		},
+	},
+	{
+		Name:      "elrc",
+		Label:     "enhanced LRC",
+		Extension: ".enhanced.lrc",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeEnhancedLRC(w, output)
+		},
+	},
+	{
+		Name:      "srt",
+		Label:     "SRT",
+		Extension: ".srt",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeSRT(w, output)
+		},
+	},
+	{
+		Name:      "vtt",
+		Label:     "WebVTT",
+		Extension: ".vtt",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeVTT(w, output)
+		},
+	},
+	{
+		Name:      "ass",
+		Label:     "ASS",
+		Extension: ".ass",
+		Write:     writeASS,
+	},
+	{
+		Name:      "ttml",
+		Label:     "TTML",
+		Extension: ".ttml",
+		Write:     writeTTML,
+	},
+	{
+		Name:      "bilingual",
+		Label:     "bilingual LRC",
+		Extension: ".bilingual.lrc",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeBilingualLRC(w, output)
+		},
+	},
+	{
+		Name:      "translated",
+		Label:     "translated LRC",
+		Extension: ".translated.lrc",
+		Write: func(w io.Writer, output *WhisperOutput, _ *Config) error {
+			return writeTranslatedLRC(w, output)
+		},
+	},
+	{
+		Name:      "romanized",
+		Label:     "romanized LRC",
+		Extension: ".romanized.lrc",
+		Write:     writeRomanizedLRC,
+	},
+}
+
+// findOutputFormat looks up a registered output format by its command-line name.
+func findOutputFormat(name string) (OutputFormat, bool) {
+	for _, format := range outputFormats {
+		if format.Name == name {
+			return format, true
+		}
+	}
+	return OutputFormat{}, false
+}
+
+// outputFormatNames returns the command-line names of all registered output formats.
+func outputFormatNames() []string {
+	names := make([]string, len(outputFormats))
+	for i, format := range outputFormats {
+		names[i] = format.Name
+	}
+	return names
+}
+
+// parseOutputFormats splits a comma-separated list of format names, normalizes and de-duplicates them,
+// and validates each against the registered output formats.
+func parseOutputFormats(value string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := findOutputFormat(name); !ok {
+			return nil, fmt.Errorf("%w: %s (supported: %s)", ErrUnsupportedOutputFormat, name, strings.Join(outputFormatNames(), ", "))
+		}
+		seen[name] = true
+		formats = append(formats, name)
+	}
+
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("%w: no formats given", ErrUnsupportedOutputFormat)
+	}
+
+	return formats, nil
+}
+
+// writeOutputFile creates the file at path and renders the transcription into it using format.
+func writeOutputFile(path string, format OutputFormat, output *WhisperOutput, config *Config) error {
+	outFile, err := os.Create(path)
+	if err != nil {
+		return newError("create "+format.Label+" file", err)
+	}
+	defer func() {
+		if err := outFile.Close(); err != nil {
+			fmt.Fprintf(uiWriter, "Warning: failed to close %s file: %v\n", format.Label, err)
+		}
+	}()
+
+	if err := format.Write(outFile, output, config); err != nil {
+		return err
+	}
+
+	file(format.Label + " file created: " + path)
+	return nil
+}
+
+// samePath reports whether two paths refer to the same file location after resolving them to absolute paths.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// renderOutputFormats loads a Whisper JSON or LRC transcription and writes every format selected in config,
+// using base plus each format's extension as the output path. Refuses to write anything when one of the
+// output paths is inputPath or any of the protected files, such as the other inputs of a convert run.
+func renderOutputFormats(inputPath, base string, protected []string, config *Config) error {
+	output, err := loadTranscript(inputPath)
+	if err != nil {
+		return err
+	}
+	config = romanizationConfig(output, config)
+
+	formats := make([]OutputFormat, 0, len(config.Formats))
+	for _, name := range config.Formats {
+		format, ok := findOutputFormat(name)
+		if !ok {
+			return newError("render output", fmt.Errorf("%w: %s", ErrUnsupportedOutputFormat, name))
+		}
+		outputPath := base + format.Extension
+		for _, input := range append([]string{inputPath}, protected...) {
+			if samePath(outputPath, input) {
+				return newError("render "+format.Label, fmt.Errorf("%w: %s", ErrOverwriteInput, input))
+			}
+		}
+		formats = append(formats, format)
+	}
+
+	for _, format := range formats {
+		step("Converting transcription to " + format.Label + " format...")
+		if err := writeOutputFile(base+format.Extension, format, output, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Values accepted by -hallucinations.
+const (
+	hallucinationsDrop = "drop"
+	hallucinationsMark = "mark"
+	hallucinationsOff  = "off"
+	// defaultHallucinationMode only marks flagged segments: the heuristics also catch real lyrics such
+	// as long "na na na" lines, so nothing is removed from the transcript unless -hallucinations=drop asks for it.
+	defaultHallucinationMode = hallucinationsMark
+)
+
+const (
+	// maxRepeatedSegments is the longest run of identical consecutive segments accepted as a sung
+	// repetition; longer runs are treated as a decoding loop and only their first segment is kept.
+	// Choruses and hooks repeat a line up to about eight times, while Whisper loops run to dozens.
+	maxRepeatedSegments = 8
+	// maxWordsPerSecond is faster than the quickest rap verses; characters of unspaced scripts count as half a word.
+	maxWordsPerSecond = 8.0
+	// maxCompressionRatio and the silence thresholds match the limits Whisper itself uses for fallback decoding.
+	maxCompressionRatio   = 2.4
+	minCompressibleLength = 50
+	minNoSpeechProb       = 0.6
+	maxSilentLogprob      = -1.0
+)
+
+var ErrInvalidHallucinationMode = errors.New("invalid hallucination mode")
+
+// hallucinationPhrases are lines Whisper is known to invent over silence and music, learned from
+// subtitle credits in its training data. A segment containing one of them is flagged.
+var hallucinationPhrases = []string{
+	"thanks for watching",
+	"thank you for watching",
+	"please subscribe",
+	"like and subscribe",
+	"subtitles by",
+	"amara.org",
+	"transcribed by",
+	"ご視聴ありがとうございました",
+	"チャンネル登録",
+	"字幕由",
+	"请不吝点赞",
+	"구독과 좋아요",
+	"시청해주셔서 감사합니다",
+	"продолжение следует",
+	"редактор субтитров",
+	"sous-titres réalisés par",
+	"untertitel im auftrag",
+	"subtítulos realizados por",
+}
+
+// hallucination is a segment flagged by detectHallucinations with the reason it was flagged.
+type hallucination struct {
+	Segment Segment
+	Reason  string
+}
+
+// validateHallucinationMode checks the -hallucinations option.
+func validateHallucinationMode(mode string) error {
+	switch mode {
+	case hallucinationsDrop, hallucinationsMark, hallucinationsOff:
+		return nil
+	}
+	return fmt.Errorf("%w: %q (valid: %s, %s, %s)", ErrInvalidHallucinationMode, mode, hallucinationsDrop, hallucinationsMark, hallucinationsOff)
+}
+
+// normalizedText lower-cases text and strips punctuation so lines can be compared and searched.
+func normalizedText(text string) string {
+	var keys []string
+	for _, word := range strings.Fields(text) {
+		for _, piece := range splitAlignmentPieces(word) {
+			if piece.Key != "" {
+				keys = append(keys, piece.Key)
+			}
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+// spokenWords estimates how many words a line takes to sing. Characters of scripts written without
+// spaces are aligned one by one, so each counts as half a word.
+func spokenWords(text string) float64 {
+	words := 0.0
+	for _, word := range strings.Fields(text) {
+		for _, piece := range splitAlignmentPieces(word) {
+			if r := []rune(piece.Key); len(r) == 1 && isUnspacedScript(r[0]) {
+				words += 0.5
+			} else if piece.Key != "" {
+				words++
+			}
+		}
+	}
+	return words
+}
+
+// compressionRatio is Whisper's repetition measure: text length divided by its zlib-compressed length.
+// Text repeating the same phrase over and over compresses far better than real lyrics. The best
+// compression level is used because Go's default level stores short inputs uncompressed.
+func compressionRatio(text string) float64 {
+	var compressed bytes.Buffer
+	writer, _ := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+	_, _ = writer.Write([]byte(text))
+	_ = writer.Close()
+	return float64(len(text)) / float64(compressed.Len())
+}
+
+// containsHallucinationPhrase reports whether normalized text contains a known hallucination phrase
+// as whole words. Unspaced scripts normalize to one key per character, so the same check covers them.
+func containsHallucinationPhrase(text string) bool {
+	for _, phrase := range hallucinationPhrases {
+		if strings.Contains(" "+text+" ", " "+normalizedText(phrase)+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentHallucinationReason checks a single segment against the phrase, silence, speed and
+// repetition heuristics and returns why it looks hallucinated, or an empty string.
+func segmentHallucinationReason(segment Segment) string {
+	text := strings.TrimSpace(segment.Text)
+	duration := segment.End - segment.Start
+
+	switch {
+	case containsHallucinationPhrase(normalizedText(text)):
+		return "known hallucination phrase"
+	case segment.NoSpeechProb >= minNoSpeechProb && segment.AvgLogprob < maxSilentLogprob:
+		return fmt.Sprintf("low confidence over silence (no_speech_prob %.2f, avg_logprob %.2f)", segment.NoSpeechProb, segment.AvgLogprob)
+	case duration <= 0 && spokenWords(text) > 0:
+		return "zero-length segment"
+	case duration > 0 && spokenWords(text)/duration > maxWordsPerSecond:
+		return fmt.Sprintf("implausible %.1f words per second", spokenWords(text)/duration)
+	case len(text) >= minCompressibleLength && compressionRatio(text) > maxCompressionRatio:
+		return "repetitive text"
+	}
+	return ""
+}
+
+// detectHallucinations returns a reason for every segment that looks hallucinated and an empty
+// string for the rest. Besides the per-segment checks, runs of more than maxRepeatedSegments
+// identical consecutive segments are flagged after their first segment.
+func detectHallucinations(segments []Segment) []string {
+	reasons := make([]string, len(segments))
+	for i, segment := range segments {
+		reasons[i] = segmentHallucinationReason(segment)
+	}
+
+	for start := 0; start < len(segments); {
+		key := normalizedText(segments[start].Text)
+		end := start + 1
+		for end < len(segments) && key != "" && normalizedText(segments[end].Text) == key {
+			end++
+		}
+		if end-start > maxRepeatedSegments {
+			for i := start + 1; i < end; i++ {
+				if reasons[i] == "" {
+					reasons[i] = fmt.Sprintf("repeated %d times in a row", end-start)
+				}
+			}
+		}
+		start = end
+	}
+
+	return reasons
+}
+
+// filterHallucinations applies the -hallucinations mode to output: flagged segments are removed
+// (drop) or kept with their reason recorded in the segment's hallucination field (mark).
+// It returns the flagged segments so callers can report them.
+func filterHallucinations(output *WhisperOutput, mode string) ([]hallucination, error) {
+	if mode == hallucinationsOff {
+		return nil, nil
+	}
+
+	reasons := detectHallucinations(output.Segments)
+	var flagged []hallucination
+	kept := make([]Segment, 0, len(output.Segments))
+	for i, segment := range output.Segments {
+		if reasons[i] == "" {
+			kept = append(kept, segment)
+			continue
+		}
+		flagged = append(flagged, hallucination{Segment: segment, Reason: reasons[i]})
+		if mode == hallucinationsMark {
+			segment.Hallucination = reasons[i]
+			kept = append(kept, segment)
+		}
+	}
+
+	if len(flagged) == 0 {
+		return nil, nil
+	}
+	if len(kept) == 0 {
+		return flagged, fmt.Errorf("%w: every segment looks hallucinated", ErrNoSegmentsFound)
+	}
+
+	if mode == hallucinationsDrop {
+		texts := make([]string, len(kept))
+		for i, segment := range kept {
+			texts[i] = strings.TrimSpace(segment.Text)
+		}
+		output.Text = strings.Join(texts, " ")
+	}
+	output.Segments = kept
+	return flagged, nil
+}
+
+// reportHallucinations lists the flagged segments with their timestamps and reasons.
+func reportHallucinations(flagged []hallucination, mode string) {
+	if len(flagged) == 0 {
+		return
+	}
+
+	action := "Removed"
+	if mode == hallucinationsMark {
+		action = "Marked"
+	}
+	warning(fmt.Sprintf("%s %d suspected hallucinations:", action, len(flagged)))
+	for _, item := range flagged {
+		text := strings.TrimSpace(item.Segment.Text)
+		if runes := []rune(text); len(runes) > 60 {
+			text = string(runes[:57]) + "..."
+		}
+		step(fmt.Sprintf("%s %s (%s)", secondsToLRCTimestamp(item.Segment.Start), text, item.Reason))
+	}
+}
+
+// hallucinationSummary is the line for the final CLI summary, or an empty string when nothing was flagged.
+func hallucinationSummary(flagged []hallucination, mode string) string {
+	if len(flagged) == 0 {
+		return ""
+	}
+	if mode == hallucinationsMark {
+		return fmt.Sprintf("Suspected hallucinations marked: %d (see \"hallucination\" in the JSON, or use -hallucinations=drop to remove them)", len(flagged))
+	}
+	return fmt.Sprintf("Suspected hallucinations removed: %d (use -hallucinations=off to keep them)", len(flagged))
+}
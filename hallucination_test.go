@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSegmentHallucinationReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		segment Segment
+		want    string
+	}{
+		{"ordinary line", Segment{Start: 0, End: 3, Text: " I walk along the empty street"}, ""},
+		{"known phrase", Segment{Start: 0, End: 3, Text: " Thanks for watching!"}, "known hallucination phrase"},
+		{"known phrase in Japanese", Segment{Start: 0, End: 3, Text: "ご視聴ありがとうございました"}, "known hallucination phrase"},
+		{"phrase inside a longer word is not flagged", Segment{Start: 0, End: 3, Text: " Subtitles byzantine"}, ""},
+		{"silence", Segment{Start: 0, End: 3, Text: " Oh", NoSpeechProb: 0.9, AvgLogprob: -1.5}, "low confidence over silence (no_speech_prob 0.90, avg_logprob -1.50)"},
+		{"confident line despite no_speech_prob", Segment{Start: 0, End: 3, Text: " Oh", NoSpeechProb: 0.9, AvgLogprob: -0.3}, ""},
+		{"zero length", Segment{Start: 5, End: 5, Text: " Hello"}, "zero-length segment"},
+		{"too fast", Segment{Start: 0, End: 1, Text: " one two three four five six seven eight nine ten"}, "implausible 10.0 words per second"},
+		{"fast rap line", Segment{Start: 0, End: 2, Text: " one two three four five six seven eight nine ten"}, ""},
+		{"unspaced characters count as half a word", Segment{Start: 0, End: 1, Text: "夢を見た夢を見た夢を見た"}, ""},
+		{"repetitive text", Segment{Start: 0, End: 30, Text: strings.Repeat(" la la la", 20)}, "repetitive text"},
+		{"short repetition", Segment{Start: 0, End: 3, Text: " la la la la"}, ""},
+	}
+
+	for _, test := range tests {
+		if got := segmentHallucinationReason(test.segment); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+// repeatedSegments returns count consecutive segments of the same line, one per two seconds from start.
+func repeatedSegments(start float64, text string, count int) []Segment {
+	segments := make([]Segment, count)
+	for i := range segments {
+		segments[i] = Segment{Start: start + float64(2*i), End: start + float64(2*i+2), Text: text}
+	}
+	return segments
+}
+
+func TestDetectHallucinationsRepeatedLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		repeats int
+		flagged int
+	}{
+		{"hook sung four times", 4, 0},
+		{"chorus line sung eight times", maxRepeatedSegments, 0},
+		{"just over the limit", maxRepeatedSegments + 1, maxRepeatedSegments},
+		{"decoding loop of 40", 40, 39},
+	}
+
+	for _, test := range tests {
+		segments := append(repeatedSegments(0, " Baby, baby, oh", test.repeats), Segment{Start: 100, End: 102, Text: " Next line"})
+		reasons := detectHallucinations(segments)
+
+		flagged := 0
+		for i, reason := range reasons {
+			if reason == "" {
+				continue
+			}
+			flagged++
+			if i == 0 {
+				t.Errorf("%s: the first line of the run was flagged", test.name)
+			}
+			if want := fmt.Sprintf("repeated %d times in a row", test.repeats); reason != want {
+				t.Errorf("%s: reason %q, want %q", test.name, reason, want)
+			}
+		}
+		if flagged != test.flagged {
+			t.Errorf("%s: flagged %d segments, want %d", test.name, flagged, test.flagged)
+		}
+	}
+}
+
+func TestDetectHallucinationsIgnoresPunctuationAndCase(t *testing.T) {
+	segments := repeatedSegments(0, " Baby, baby, oh", maxRepeatedSegments)
+	segments = append(segments, Segment{Start: 50, End: 52, Text: " baby baby OH!"})
+
+	if reasons := detectHallucinations(segments); reasons[len(reasons)-1] == "" {
+		t.Error("a repeat differing only in punctuation and case ended the run")
+	}
+}
+
+func TestFilterHallucinationsModes(t *testing.T) {
+	newOutput := func() *WhisperOutput {
+		return &WhisperOutput{Text: "Real line Thanks for watching", Segments: []Segment{
+			{Start: 0, End: 3, Text: " Real line"},
+			{Start: 3, End: 6, Text: " Thanks for watching"},
+		}}
+	}
+
+	dropped := newOutput()
+	flagged, err := filterHallucinations(dropped, hallucinationsDrop)
+	if err != nil || len(flagged) != 1 || len(dropped.Segments) != 1 || dropped.Text != "Real line" {
+		t.Errorf("drop: flagged %d, err %v, segments %+v, text %q", len(flagged), err, dropped.Segments, dropped.Text)
+	}
+
+	marked := newOutput()
+	flagged, err = filterHallucinations(marked, hallucinationsMark)
+	if err != nil || len(flagged) != 1 || len(marked.Segments) != 2 || marked.Segments[1].Hallucination != "known hallucination phrase" {
+		t.Errorf("mark: flagged %d, err %v, segments %+v", len(flagged), err, marked.Segments)
+	}
+
+	off := newOutput()
+	if flagged, err := filterHallucinations(off, hallucinationsOff); err != nil || flagged != nil || len(off.Segments) != 2 {
+		t.Errorf("off: flagged %d, err %v, segments %+v", len(flagged), err, off.Segments)
+	}
+
+	all := &WhisperOutput{Segments: []Segment{{Start: 0, End: 3, Text: " Please subscribe"}}}
+	if _, err := filterHallucinations(all, hallucinationsDrop); !errors.Is(err, ErrNoSegmentsFound) {
+		t.Errorf("dropping every segment: err = %v, want %v", err, ErrNoSegmentsFound)
+	}
+}
+
+func TestDefaultModeKeepsRepetitiveLyrics(t *testing.T) {
+	chorus := " Na na na na, na na na na, hey hey hey, goodbye. Na na na na, na na na na, hey hey hey, goodbye."
+	output := &WhisperOutput{Text: "Verse line" + chorus, Segments: []Segment{
+		{Start: 0, End: 4, Text: " Verse line"},
+		{Start: 4, End: 16, Text: chorus},
+	}}
+	if reason := segmentHallucinationReason(output.Segments[1]); reason != "repetitive text" {
+		t.Fatalf("the chorus is expected to trip the compression check, got %q", reason)
+	}
+
+	flagged, err := filterHallucinations(output, defaultHallucinationMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flagged) != 1 || len(output.Segments) != 2 || output.Segments[1].Text != chorus {
+		t.Errorf("default mode removed a real lyric line: segments %+v", output.Segments)
+	}
+	if output.Text != "Verse line"+chorus {
+		t.Errorf("default mode rewrote the text to %q", output.Text)
+	}
+}
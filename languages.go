@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// autoLanguage is the -language value that lets the backend detect the spoken language.
+const autoLanguage = "auto"
+
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// whisperLanguages maps the language codes Whisper supports to their English names.
+// Backends report the detected language either as a code or a name, so both are accepted.
+var whisperLanguages = map[string]string{
+	"af": "afrikaans", "am": "amharic", "ar": "arabic", "as": "assamese", "az": "azerbaijani",
+	"ba": "bashkir", "be": "belarusian", "bg": "bulgarian", "bn": "bengali", "bo": "tibetan",
+	"br": "breton", "bs": "bosnian", "ca": "catalan", "cs": "czech", "cy": "welsh",
+	"da": "danish", "de": "german", "el": "greek", "en": "english", "es": "spanish",
+	"et": "estonian", "eu": "basque", "fa": "persian", "fi": "finnish", "fo": "faroese",
+	"fr": "french", "gl": "galician", "gu": "gujarati", "ha": "hausa", "haw": "hawaiian",
+	"he": "hebrew", "hi": "hindi", "hr": "croatian", "ht": "haitian creole", "hu": "hungarian",
+	"hy": "armenian", "id": "indonesian", "is": "icelandic", "it": "italian", "ja": "japanese",
+	"jw": "javanese", "ka": "georgian", "kk": "kazakh", "km": "khmer", "kn": "kannada",
+	"ko": "korean", "la": "latin", "lb": "luxembourgish", "ln": "lingala", "lo": "lao",
+	"lt": "lithuanian", "lv": "latvian", "mg": "malagasy", "mi": "maori", "mk": "macedonian",
+	"ml": "malayalam", "mn": "mongolian", "mr": "marathi", "ms": "malay", "mt": "maltese",
+	"my": "myanmar", "ne": "nepali", "nl": "dutch", "nn": "nynorsk", "no": "norwegian",
+	"oc": "occitan", "pa": "punjabi", "pl": "polish", "ps": "pashto", "pt": "portuguese",
+	"ro": "romanian", "ru": "russian", "sa": "sanskrit", "sd": "sindhi", "si": "sinhala",
+	"sk": "slovak", "sl": "slovenian", "sn": "shona", "so": "somali", "sq": "albanian",
+	"sr": "serbian", "su": "sundanese", "sv": "swedish", "sw": "swahili", "ta": "tamil",
+	"te": "telugu", "tg": "tajik", "th": "thai", "tk": "turkmen", "tl": "tagalog",
+	"tr": "turkish", "tt": "tatar", "uk": "ukrainian", "ur": "urdu", "uz": "uzbek",
+	"vi": "vietnamese", "yi": "yiddish", "yo": "yoruba", "yue": "cantonese", "zh": "chinese",
+}
+
+// whisperCppDetectedLanguagePattern matches whisper.cpp's "auto-detected language: ja (p = 0.970)" log line.
+var whisperCppDetectedLanguagePattern = regexp.MustCompile(`auto-detected language:\s*([A-Za-z-]+)\s*\(p\s*=\s*([0-9.]+)\)`)
+
+// fasterWhisperDetectedLanguagePattern matches whisper-ctranslate2's "Detected language 'Japanese' with
+// probability 0.97" line. The JSON it writes carries the language but not the probability.
+var fasterWhisperDetectedLanguagePattern = regexp.MustCompile(`Detected language '([^']+)' with probability ([0-9.]+)`)
+
+// normalizeLanguageCode converts a language code or English language name to its Whisper code.
+// Returns an empty string when the value is not a language Whisper knows.
+func normalizeLanguageCode(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if _, ok := whisperLanguages[value]; ok {
+		return value
+	}
+	for code, name := range whisperLanguages {
+		if name == value {
+			return code
+		}
+	}
+	return ""
+}
+
+// parseLanguageOption validates the -language option, returning either "auto" or a Whisper language code.
+func parseLanguageOption(value string) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(value), autoLanguage) {
+		return autoLanguage, nil
+	}
+	code := normalizeLanguageCode(value)
+	if code == "" {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLanguage, value)
+	}
+	return code, nil
+}
+
+// parseWhisperCppDetectedLanguage extracts the detected language and its probability from whisper.cpp logs.
+func parseWhisperCppDetectedLanguage(logs string) (string, float64) {
+	match := whisperCppDetectedLanguagePattern.FindStringSubmatch(logs)
+	if match == nil {
+		return "", 0
+	}
+	probability, _ := strconv.ParseFloat(match[2], 64)
+	return match[1], probability
+}
+
+// parseFasterWhisperDetectedLanguage extracts the detected language code and its probability from faster-whisper output.
+func parseFasterWhisperDetectedLanguage(logs string) (string, float64) {
+	match := fasterWhisperDetectedLanguagePattern.FindStringSubmatch(logs)
+	if match == nil {
+		return "", 0
+	}
+	probability, _ := strconv.ParseFloat(match[2], 64)
+	return normalizeLanguageCode(match[1]), probability
+}
+
+// resolveTranscriptLanguage normalizes the language a backend reported and falls back to the forced
+// -language value when the backend did not report one. The result is stored back on the output.
+func resolveTranscriptLanguage(output *WhisperOutput, config *Config) {
+	language := normalizeLanguageCode(output.Language)
+	if language == "" && config.Language != autoLanguage {
+		language = config.Language
+	}
+	output.Language = language
+}
+
+// reportDetectedLanguage prints the language a backend detected and its probability. openai-whisper
+// does not report a probability, so the message says so rather than leaving it out silently.
+func reportDetectedLanguage(output *WhisperOutput, backend string) {
+	if output.Language == "" {
+		warning("Could not determine the transcription language")
+		return
+	}
+	message := "Detected language: " + output.Language
+	if output.LanguageProbability > 0 {
+		message += fmt.Sprintf(" (%.0f%% probability)", output.LanguageProbability*100)
+	} else {
+		message += " (probability not reported by the " + backend + " backend)"
+	}
+	info(message)
+}
+
+// transcriptLanguage returns the best known language code for a transcript, or "" if unknown.
+func transcriptLanguage(output *WhisperOutput, config *Config) string {
+	if output.Language != "" {
+		return output.Language
+	}
+	if config.Language != autoLanguage {
+		return config.Language
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestParseDetectedLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		parse       func(string) (string, float64)
+		logs        string
+		language    string
+		probability float64
+	}{
+		{"whisper.cpp", parseWhisperCppDetectedLanguage, "whisper_full_with_state: auto-detected language: ja (p = 0.970123)\n", "ja", 0.970123},
+		{"faster-whisper", parseFasterWhisperDetectedLanguage, "Detected language 'Japanese' with probability 0.97\n[00:00.000 --> 00:02.000] ...\n", "ja", 0.97},
+		{"faster-whisper multi-word name", parseFasterWhisperDetectedLanguage, "Detected language 'Haitian Creole' with probability 0.51\n", "ht", 0.51},
+		{"no detection", parseFasterWhisperDetectedLanguage, "[00:00.000 --> 00:02.000] Hello\n", "", 0},
+	}
+
+	for _, test := range tests {
+		language, probability := test.parse(test.logs)
+		if language != test.language || probability != test.probability {
+			t.Errorf("%s: got %q %v, want %q %v", test.name, language, probability, test.language, test.probability)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidLRCTimestamp = errors.New("invalid LRC timestamp")
+
+var (
+	lrcTimeTagPattern = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcIDTagPattern   = regexp.MustCompile(`^\[([A-Za-z#]+):(.*)\]$`)
+	lrcWordTagPattern = regexp.MustCompile(`<(\d+):(\d{1,2})(?:[.:](\d{1,3}))?>`)
+)
+
+// LRCTags holds the ID tags found in an LRC header.
+// Offset is in milliseconds and Length in seconds; zero values mean the tag was absent.
+type LRCTags struct {
+	Title    string
+	Artist   string
+	Album    string
+	Author   string
+	By       string
+	Language string
+	Offset   int
+	Length   float64
+}
+
+// LRCDocument is a parsed LRC file: its ID tags, the lyrics as the Segment/Word model shared with
+// WhisperOutput, and any problems found while parsing so files can be validated before publishing.
+type LRCDocument struct {
+	Tags     LRCTags
+	Output   *WhisperOutput
+	Problems []string
+}
+
+// secondsToEnhancedLRCTimestamp converts floating-point seconds to the inline word tag <MM:SS.XX>
+// used by the enhanced LRC (A2) extension for karaoke-style word highlighting.
+func secondsToEnhancedLRCTimestamp(seconds float64) string {
+	return "<" + formatLRCTime(seconds) + ">"
+}
+
+// lrcHeader builds the ID-tag header for an LRC file from the transcript metadata and language.
+// Only tags with known values are written; the [by:] tag always credits the EchoWave version.
+func lrcHeader(output *WhisperOutput) string {
+	var header strings.Builder
+	if metadata := output.Metadata; metadata != nil {
+		for _, tag := range []struct{ key, value string }{
+			{"ti", metadata.Title},
+			{"ar", metadata.Artist},
+			{"al", metadata.Album},
+			{"au", metadata.Author},
+		} {
+			if value := strings.TrimSpace(tag.value); value != "" {
+				header.WriteString(fmt.Sprintf("[%s:%s]\n", tag.key, value))
+			}
+		}
+		if metadata.Duration > 0 {
+			total := int(metadata.Duration + 0.5)
+			header.WriteString(fmt.Sprintf("[length:%02d:%02d]\n", total/secondsPerMinute, total%secondsPerMinute))
+		}
+	}
+	if output.Language != "" {
+		header.WriteString("[la:" + output.Language + "]\n")
+	}
+	header.WriteString("[by:EchoWave v" + VERSION + "]\n")
+	return header.String()
+}
+
+// writeLRC renders the ID-tag header followed by one [MM:SS.XX] line per segment using the segment start time.
+func writeLRC(w io.Writer, output *WhisperOutput) error {
+	if _, err := io.WriteString(w, lrcHeader(output)); err != nil {
+		return newError("write LRC header", err)
+	}
+	for _, segment := range output.Segments {
+		line := fmt.Sprintf("%s %s\n", secondsToLRCTimestamp(segment.Start), strings.TrimSpace(segment.Text))
+		if _, err := io.WriteString(w, line); err != nil {
+			return newError("write LRC content", err)
+		}
+	}
+	return nil
+}
+
+// writeEnhancedLRC renders the ID-tag header and segments as enhanced LRC lines with an inline <MM:SS.XX> tag before each word
+// and a closing tag at the end of the last word. Segments without word timings fall back to plain LRC lines.
+func writeEnhancedLRC(w io.Writer, output *WhisperOutput) error {
+	if _, err := io.WriteString(w, lrcHeader(output)); err != nil {
+		return newError("write enhanced LRC header", err)
+	}
+	for _, segment := range output.Segments {
+		if _, err := io.WriteString(w, enhancedLRCLine(segment)+"\n"); err != nil {
+			return newError("write enhanced LRC content", err)
+		}
+	}
+	return nil
+}
+
+// enhancedLRCLine builds a single enhanced LRC line for a segment.
+// Words with empty text after trimming are skipped so stray whitespace tokens do not produce empty tags.
+func enhancedLRCLine(segment Segment) string {
+	var line strings.Builder
+	line.WriteString(secondsToLRCTimestamp(segment.Start))
+
+	lastEnd := -1.0
+	for _, word := range segment.Words {
+		text := strings.TrimSpace(word.Word)
+		if text == "" {
+			continue
+		}
+		line.WriteString(" " + secondsToEnhancedLRCTimestamp(word.Start) + " " + text)
+		lastEnd = word.End
+	}
+
+	if lastEnd < 0 {
+		return secondsToLRCTimestamp(segment.Start) + " " + strings.TrimSpace(segment.Text)
+	}
+
+	line.WriteString(" " + secondsToEnhancedLRCTimestamp(lastEnd))
+	return line.String()
+}
+
+// parseLRCTime converts the minute, second and fractional parts of an LRC timestamp into seconds.
+// The fraction is interpreted by its digit count, so "5" is tenths, "50" hundredths and "500" milliseconds.
+func parseLRCTime(minutes, seconds, fraction string) (float64, error) {
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s:%s", ErrInvalidLRCTimestamp, minutes, seconds)
+	}
+	sec, err := strconv.Atoi(seconds)
+	if err != nil || sec >= secondsPerMinute {
+		return 0, fmt.Errorf("%w: %s:%s", ErrInvalidLRCTimestamp, minutes, seconds)
+	}
+
+	value := float64(m*secondsPerMinute + sec)
+	if fraction != "" {
+		f, err := strconv.Atoi(fraction)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s:%s.%s", ErrInvalidLRCTimestamp, minutes, seconds, fraction)
+		}
+		divisor := 1.0
+		for range fraction {
+			divisor *= 10
+		}
+		value += float64(f) / divisor
+	}
+
+	return value, nil
+}
+
+// parseLRCLength converts a [length:] value such as "03:45" or "03:45.20" into seconds.
+func parseLRCLength(value string) (float64, error) {
+	match := lrcTimeTagPattern.FindStringSubmatch("[" + value + "]")
+	if match == nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidLRCTimestamp, value)
+	}
+	return parseLRCTime(match[1], match[2], match[3])
+}
+
+// applyLRCTag stores a recognized ID tag in tags. Unknown tags are reported as problems but not fatal.
+func applyLRCTag(tags *LRCTags, key, value string, lineNumber int, problems *[]string) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(key) {
+	case "ti":
+		tags.Title = value
+	case "ar":
+		tags.Artist = value
+	case "al":
+		tags.Album = value
+	case "au":
+		tags.Author = value
+	case "by":
+		tags.By = value
+	case "la":
+		tags.Language = value
+	case "offset":
+		offset, err := strconv.Atoi(strings.TrimPrefix(value, "+"))
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("line %d: invalid offset %q", lineNumber, value))
+			return
+		}
+		tags.Offset = offset
+	case "length":
+		length, err := parseLRCLength(value)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("line %d: invalid length %q", lineNumber, value))
+			return
+		}
+		tags.Length = length
+	case "re", "ve", "#":
+	default:
+		*problems = append(*problems, fmt.Sprintf("line %d: unknown tag [%s:]", lineNumber, key))
+	}
+}
+
+// parseLRCWords splits enhanced LRC text into timed words using its <MM:SS.XX> tags.
+// Each word ends where the next tag begins; a trailing tag closes the last word.
+// Returns the plain text with tags removed alongside the words (nil when the text has no tags).
+func parseLRCWords(text string, lineNumber int, problems *[]string) (string, []Word) {
+	matches := lrcWordTagPattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return strings.Join(strings.Fields(text), " "), nil
+	}
+
+	var words []Word
+	var plain []string
+	for i, match := range matches {
+		start, err := parseLRCTime(text[match[2]:match[3]], text[match[4]:match[5]], submatch(text, match, 3))
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("line %d: %v", lineNumber, err))
+			continue
+		}
+
+		if n := len(words); n > 0 && words[n-1].End == 0 {
+			words[n-1].End = start
+		}
+
+		segmentEndIndex := len(text)
+		if i+1 < len(matches) {
+			segmentEndIndex = matches[i+1][0]
+		}
+		wordText := strings.TrimSpace(text[match[1]:segmentEndIndex])
+		if wordText == "" {
+			continue
+		}
+
+		plain = append(plain, wordText)
+		words = append(words, Word{Word: " " + wordText, Start: start, Probability: 1})
+	}
+
+	if n := len(words); n > 0 && words[n-1].End == 0 {
+		words[n-1].End = words[n-1].Start
+	}
+
+	if prefix := strings.TrimSpace(text[:matches[0][0]]); prefix != "" {
+		plain = append([]string{prefix}, plain...)
+	}
+
+	return strings.Join(plain, " "), words
+}
+
+// submatch returns the text of capture group n from a FindStringSubmatchIndex result, or "" when unmatched.
+func submatch(text string, match []int, n int) string {
+	if match[2*n] < 0 {
+		return ""
+	}
+	return text[match[2*n]:match[2*n+1]]
+}
+
+// parseLRC reads an LRC document, including ID tags, lines with several timestamps and enhanced
+// per-word tags, into the Segment/Word model. Lines repeated under multiple timestamps become one
+// segment per timestamp, segments are sorted by start time and the [offset:] tag is applied.
+func parseLRC(r io.Reader) (*LRCDocument, error) {
+	doc := &LRCDocument{Output: &WhisperOutput{}}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	lastStart := -1.0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+
+		var times []float64
+		rest := line
+		for {
+			match := lrcTimeTagPattern.FindStringSubmatch(rest)
+			if match == nil {
+				break
+			}
+			t, err := parseLRCTime(match[1], match[2], match[3])
+			if err != nil {
+				doc.Problems = append(doc.Problems, fmt.Sprintf("line %d: %v", lineNumber, err))
+			} else {
+				times = append(times, t)
+			}
+			rest = rest[len(match[0]):]
+		}
+
+		if len(times) == 0 {
+			if tag := lrcIDTagPattern.FindStringSubmatch(line); tag != nil {
+				applyLRCTag(&doc.Tags, tag[1], tag[2], lineNumber, &doc.Problems)
+			} else {
+				doc.Problems = append(doc.Problems, fmt.Sprintf("line %d: no timestamp, ignored", lineNumber))
+			}
+			continue
+		}
+
+		// Lines with several timestamps legitimately jump back in time (repeated choruses),
+		// so ordering is only checked between single-timestamp lines.
+		if len(times) == 1 {
+			if times[0] < lastStart {
+				doc.Problems = append(doc.Problems, fmt.Sprintf("line %d: timestamp %s is earlier than the previous line",
+					lineNumber, strings.Trim(secondsToLRCTimestamp(times[0]), "[]")))
+			}
+			lastStart = times[0]
+		}
+
+		text, words := parseLRCWords(rest, lineNumber, &doc.Problems)
+		for _, t := range times {
+			shift := t - times[0]
+			segmentWords := make([]Word, len(words))
+			for i, word := range words {
+				word.Start += shift
+				word.End += shift
+				segmentWords[i] = word
+			}
+			if len(words) == 0 {
+				segmentWords = nil
+			}
+
+			doc.Output.Segments = append(doc.Output.Segments, Segment{
+				Start:      t,
+				Text:       text,
+				Confidence: 1,
+				Words:      segmentWords,
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, newError("read LRC", err)
+	}
+
+	if len(doc.Output.Segments) == 0 {
+		return nil, newError("parse LRC", ErrNoSegmentsFound)
+	}
+
+	sort.SliceStable(doc.Output.Segments, func(i, j int) bool {
+		return doc.Output.Segments[i].Start < doc.Output.Segments[j].Start
+	})
+
+	// Words whose line had no closing tag run until the next line starts.
+	for i := range doc.Output.Segments {
+		words := doc.Output.Segments[i].Words
+		if n := len(words); n > 0 && words[n-1].End <= words[n-1].Start && i+1 < len(doc.Output.Segments) {
+			words[n-1].End = doc.Output.Segments[i+1].Start
+		}
+	}
+
+	if doc.Tags.Offset != 0 {
+		applyLRCOffset(doc.Output, doc.Tags.Offset)
+	}
+
+	doc.Output.Language = normalizeLanguageCode(doc.Tags.Language)
+	doc.Output.Metadata = &TranscriptMetadata{
+		Title:    doc.Tags.Title,
+		Artist:   doc.Tags.Artist,
+		Album:    doc.Tags.Album,
+		Author:   doc.Tags.Author,
+		Duration: doc.Tags.Length,
+	}
+
+	return doc, nil
+}
+
+// applyLRCOffset shifts every segment and word by an [offset:] value in milliseconds.
+// A positive offset makes lyrics appear sooner; times are clamped at zero.
+func applyLRCOffset(output *WhisperOutput, offsetMs int) {
+	shift := float64(offsetMs) / millisecondsPerUnit
+	clamp := func(t float64) float64 {
+		if t-shift < 0 {
+			return 0
+		}
+		return t - shift
+	}
+
+	for i := range output.Segments {
+		segment := &output.Segments[i]
+		segment.Start = clamp(segment.Start)
+		for j := range segment.Words {
+			segment.Words[j].Start = clamp(segment.Words[j].Start)
+			segment.Words[j].End = clamp(segment.Words[j].End)
+		}
+	}
+}
+
+// parseLRCFile opens and parses the LRC file at path.
+func parseLRCFile(path string) (*LRCDocument, error) {
+	lrcFile, err := os.Open(path)
+	if err != nil {
+		return nil, newError("open LRC file", err)
+	}
+	defer lrcFile.Close()
+
+	return parseLRC(lrcFile)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+// lrcTolerance is the rounding error allowed by the centisecond resolution of LRC timestamps.
+const lrcTolerance = 0.0051
+
+func TestFormatLRCTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "00:00.00"},
+		{-1, "00:00.00"},
+		{5.5, "00:05.50"},
+		{59.994, "00:59.99"},
+		{59.996, "01:00.00"},
+		{61.234, "01:01.23"},
+		{3599.999, "60:00.00"},
+	}
+
+	for _, test := range tests {
+		if got := formatLRCTime(test.seconds); got != test.want {
+			t.Errorf("formatLRCTime(%v) = %q, want %q", test.seconds, got, test.want)
+		}
+	}
+}
+
+// parseLRCString parses an LRC document held in a string and fails the test on errors.
+func parseLRCString(t *testing.T, text string) *LRCDocument {
+	t.Helper()
+	doc, err := parseLRC(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("parseLRC: %v", err)
+	}
+	return doc
+}
+
+// roundTrip renders output with write and parses the result back.
+func roundTrip(t *testing.T, output *WhisperOutput, write func(io.Writer, *WhisperOutput) error) *LRCDocument {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := write(&buf, output); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	doc := parseLRCString(t, buf.String())
+	if len(doc.Problems) > 0 {
+		t.Fatalf("parsing written LRC reported problems %v in:\n%s", doc.Problems, buf.String())
+	}
+	return doc
+}
+
+func TestLRCRoundTrip(t *testing.T) {
+	output := &WhisperOutput{
+		Language: "en",
+		Segments: []Segment{
+			{Start: 0, Text: " First line"},
+			{Start: 59.996, Text: "Rounds up to a full minute"},
+			{Start: 125.5, Text: "  Third line  "},
+		},
+	}
+
+	doc := roundTrip(t, output, writeLRC)
+
+	if len(doc.Output.Segments) != len(output.Segments) {
+		t.Fatalf("got %d segments, want %d", len(doc.Output.Segments), len(output.Segments))
+	}
+	for i, want := range output.Segments {
+		got := doc.Output.Segments[i]
+		if math.Abs(got.Start-want.Start) > lrcTolerance {
+			t.Errorf("segment %d start = %v, want %v", i, got.Start, want.Start)
+		}
+		if got.Text != strings.TrimSpace(want.Text) {
+			t.Errorf("segment %d text = %q, want %q", i, got.Text, strings.TrimSpace(want.Text))
+		}
+	}
+	if doc.Output.Language != "en" {
+		t.Errorf("language = %q, want en", doc.Output.Language)
+	}
+}
+
+func TestEnhancedLRCRoundTrip(t *testing.T) {
+	output := &WhisperOutput{
+		Segments: []Segment{
+			{Start: 58.5, Text: "over the minute", Words: []Word{
+				{Word: " over", Start: 58.5, End: 59.2},
+				{Word: " the", Start: 59.2, End: 59.996},
+				{Word: " minute", Start: 59.996, End: 61.1},
+			}},
+			{Start: 62, Text: "no word timings"},
+		},
+	}
+
+	doc := roundTrip(t, output, writeEnhancedLRC)
+
+	if len(doc.Output.Segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(doc.Output.Segments))
+	}
+	first := doc.Output.Segments[0]
+	if first.Text != "over the minute" {
+		t.Errorf("text = %q, want %q", first.Text, "over the minute")
+	}
+	if len(first.Words) != 3 {
+		t.Fatalf("got %d words, want 3", len(first.Words))
+	}
+	for i, want := range output.Segments[0].Words {
+		got := first.Words[i]
+		if got.Word != want.Word || math.Abs(got.Start-want.Start) > lrcTolerance || math.Abs(got.End-want.End) > lrcTolerance {
+			t.Errorf("word %d = %+v, want %+v", i, got, want)
+		}
+	}
+	if second := doc.Output.Segments[1]; second.Text != "no word timings" || second.Words != nil {
+		t.Errorf("segment without words = %+v", second)
+	}
+}
+
+func TestLRCMultipleTimestampsRoundTrip(t *testing.T) {
+	doc := parseLRCString(t, "[00:10.00]Verse\n[00:20.00][01:05.50]Chorus\n[00:30.00]Bridge\n")
+
+	want := []struct {
+		start float64
+		text  string
+	}{
+		{10, "Verse"},
+		{20, "Chorus"},
+		{30, "Bridge"},
+		{65.5, "Chorus"},
+	}
+	check := func(doc *LRCDocument) {
+		t.Helper()
+		if len(doc.Output.Segments) != len(want) {
+			t.Fatalf("got %d segments, want %d", len(doc.Output.Segments), len(want))
+		}
+		for i, w := range want {
+			got := doc.Output.Segments[i]
+			if math.Abs(got.Start-w.start) > lrcTolerance || got.Text != w.text {
+				t.Errorf("segment %d = %v %q, want %v %q", i, got.Start, got.Text, w.start, w.text)
+			}
+		}
+	}
+
+	check(doc)
+	check(roundTrip(t, doc.Output, writeLRC))
+}
+
+func TestLRCOffsetRoundTrip(t *testing.T) {
+	doc := parseLRCString(t, "[offset:+500]\n[00:01.00]<00:01.00> one <00:01.40> two <00:02.00>\n[00:00.20]clamped\n")
+
+	if doc.Tags.Offset != 500 {
+		t.Fatalf("offset = %d, want 500", doc.Tags.Offset)
+	}
+	segments := doc.Output.Segments
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(segments))
+	}
+	if segments[0].Start != 0 || segments[0].Text != "clamped" {
+		t.Errorf("clamped segment = %v %q, want 0 %q", segments[0].Start, segments[0].Text, "clamped")
+	}
+	if math.Abs(segments[1].Start-0.5) > lrcTolerance {
+		t.Errorf("shifted start = %v, want 0.5", segments[1].Start)
+	}
+	if words := segments[1].Words; len(words) != 2 || math.Abs(words[1].Start-0.9) > lrcTolerance || math.Abs(words[1].End-1.5) > lrcTolerance {
+		t.Errorf("shifted words = %+v", words)
+	}
+
+	// The offset is applied when parsing, so the written file carries shifted times and no [offset:] tag.
+	again := roundTrip(t, doc.Output, writeEnhancedLRC)
+	if again.Tags.Offset != 0 {
+		t.Errorf("rewritten offset = %d, want 0", again.Tags.Offset)
+	}
+	if math.Abs(again.Output.Segments[1].Start-0.5) > lrcTolerance || math.Abs(again.Output.Segments[1].Words[1].Start-0.9) > lrcTolerance {
+		t.Errorf("rewritten segment = %+v", again.Output.Segments[1])
+	}
+}
+
+func TestParseLRCTimeRejectsInvalidSeconds(t *testing.T) {
+	if _, err := parseLRCTime("00", "60", "00"); err == nil {
+		t.Error("parseLRCTime accepted 00:60.00")
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minWordSimilarity is how alike a lyric word and a recognized word must be to count as a match,
+// so misheard spellings ("gonna" for "going") still anchor the timing.
+const minWordSimilarity = 0.6
+
+// Backtracking directions recorded by alignLyricUnits.
+const (
+	alignMatch uint8 = iota
+	alignSkipLyric
+	alignSkipRecognized
+)
+
+var (
+	ErrEmptyLyrics       = errors.New("lyrics file contains no lyric lines")
+	ErrLyricsNotAligned  = errors.New("no lyric words matched the transcription")
+	sectionHeaderPattern = regexp.MustCompile(`^\[[^\]]*\]$`)
+)
+
+// lyricLine is one line of the user's lyrics file with its line number for reporting.
+type lyricLine struct {
+	Number int
+	Text   string
+}
+
+// alignmentPiece is the smallest piece of text aligned on its own: a space-separated word, or a single
+// character in scripts written without spaces. Key is the normalized form compared during alignment.
+type alignmentPiece struct {
+	Text string
+	Key  string
+}
+
+// lyricUnit is an alignment piece of the lyrics with the timing inferred for it.
+type lyricUnit struct {
+	alignmentPiece
+	Line        int
+	Start       float64
+	End         float64
+	Probability float64
+	Matched     bool
+}
+
+// recognizedUnit is an alignment piece of the transcription with its timing and probability.
+type recognizedUnit struct {
+	Key         string
+	Start       float64
+	End         float64
+	Probability float64
+}
+
+// readLyricsFile reads the plain-text lyrics to align. Blank lines and section headers such as
+// "[Chorus]" are skipped; every other line is kept exactly as written, minus surrounding whitespace.
+func readLyricsFile(path string) ([]lyricLine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []lyricLine
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimSpace(strings.TrimPrefix(text, "\ufeff"))
+		if text == "" || sectionHeaderPattern.MatchString(text) {
+			continue
+		}
+		lines = append(lines, lyricLine{Number: i + 1, Text: text})
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmptyLyrics, path)
+	}
+	return lines, nil
+}
+
+// isUnspacedScript reports whether r belongs to a script written without spaces between words,
+// where each character is aligned separately.
+func isUnspacedScript(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai) || r == 'ー'
+}
+
+// splitAlignmentPieces splits a space-separated word into alignment pieces. Characters of unspaced
+// scripts become pieces of their own; punctuation stays attached to the neighbouring piece and is left
+// out of the key, which is lower-cased letters and digits only.
+func splitAlignmentPieces(word string) []alignmentPiece {
+	var pieces []alignmentPiece
+	var current alignmentPiece
+
+	flush := func() {
+		pieces = append(pieces, current)
+		current = alignmentPiece{}
+	}
+
+	for _, r := range word {
+		switch {
+		case isUnspacedScript(r):
+			if current.Key != "" {
+				flush()
+			}
+			current.Text += string(r)
+			current.Key += string(r)
+			flush()
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			current.Text += string(r)
+			current.Key += string(unicode.ToLower(r))
+		default:
+			current.Text += string(r)
+		}
+	}
+
+	if current.Text != "" {
+		if current.Key == "" && len(pieces) > 0 {
+			pieces[len(pieces)-1].Text += current.Text
+		} else {
+			pieces = append(pieces, current)
+		}
+	}
+	return pieces
+}
+
+// lyricUnits splits every lyric line into units. The first piece of each word carries a leading space,
+// following Whisper's word convention.
+func lyricUnits(lines []lyricLine) []lyricUnit {
+	var units []lyricUnit
+	for i, line := range lines {
+		for _, word := range strings.Fields(line.Text) {
+			for j, piece := range splitAlignmentPieces(word) {
+				if j == 0 {
+					piece.Text = " " + piece.Text
+				}
+				units = append(units, lyricUnit{alignmentPiece: piece, Line: i})
+			}
+		}
+	}
+	return units
+}
+
+// segmentWords returns a segment's word timings. Segments transcribed without them are split into
+// words spread evenly over the segment, carrying its confidence.
+func segmentWords(segment Segment) []Word {
+	if len(segment.Words) > 0 {
+		return segment.Words
+	}
+
+	fields := strings.Fields(segment.Text)
+	probability := segment.Confidence
+	if probability == 0 {
+		probability = max(1+segment.AvgLogprob, 0)
+	}
+
+	words := make([]Word, len(fields))
+	step := (segment.End - segment.Start) / float64(max(len(fields), 1))
+	for i, field := range fields {
+		words[i] = Word{Word: " " + field, Start: segment.Start + step*float64(i), End: segment.Start + step*float64(i+1), Probability: probability}
+	}
+	return words
+}
+
+// recognizedUnits splits the transcribed words into units. A word split into several characters
+// shares its time span evenly between them.
+func recognizedUnits(output *WhisperOutput) []recognizedUnit {
+	var units []recognizedUnit
+	for _, segment := range output.Segments {
+		for _, word := range segmentWords(segment) {
+			var pieces []alignmentPiece
+			for _, piece := range splitAlignmentPieces(strings.TrimSpace(word.Word)) {
+				if piece.Key != "" {
+					pieces = append(pieces, piece)
+				}
+			}
+
+			step := (word.End - word.Start) / float64(max(len(pieces), 1))
+			for i, piece := range pieces {
+				units = append(units, recognizedUnit{
+					Key:         piece.Key,
+					Start:       word.Start + step*float64(i),
+					End:         word.Start + step*float64(i+1),
+					Probability: word.Probability,
+				})
+			}
+		}
+	}
+	return units
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in runes.
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+// keySimilarity scores two normalized keys from 0 (nothing in common) to 1 (identical).
+func keySimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// alignLyricUnits aligns lyric units to recognized units with a word-level edit distance: pairing two
+// units costs their dissimilarity and skipping a unit on either side costs 1. Recognized units before
+// the first and after the last lyric are skipped for free, so intros and outros do not pull the
+// alignment. Lyric units paired with a similar enough recognized unit take over its timing.
+func alignLyricUnits(lyrics []lyricUnit, recognized []recognizedUnit) {
+	n, m := len(lyrics), len(recognized)
+	directions := make([]uint8, (n+1)*(m+1))
+	previous := make([]float64, m+1)
+	current := make([]float64, m+1)
+
+	for j := 1; j <= m; j++ {
+		directions[j] = alignSkipRecognized
+	}
+
+	for i := 1; i <= n; i++ {
+		current[0] = float64(i)
+		directions[i*(m+1)] = alignSkipLyric
+		for j := 1; j <= m; j++ {
+			best := previous[j-1] + 1 - keySimilarity(lyrics[i-1].Key, recognized[j-1].Key)
+			direction := alignMatch
+			if cost := previous[j] + 1; cost < best {
+				best, direction = cost, alignSkipLyric
+			}
+			if cost := current[j-1] + 1; cost < best {
+				best, direction = cost, alignSkipRecognized
+			}
+			current[j] = best
+			directions[i*(m+1)+j] = direction
+		}
+		previous, current = current, previous
+	}
+
+	end := 0
+	for j := 1; j <= m; j++ {
+		if previous[j] < previous[end] {
+			end = j
+		}
+	}
+
+	for i, j := n, end; i > 0; {
+		switch directions[i*(m+1)+j] {
+		case alignMatch:
+			unit, match := &lyrics[i-1], recognized[j-1]
+			if keySimilarity(unit.Key, match.Key) >= minWordSimilarity {
+				unit.Start, unit.End, unit.Probability, unit.Matched = match.Start, match.End, match.Probability, true
+			}
+			i, j = i-1, j-1
+		case alignSkipLyric:
+			i--
+		default:
+			j--
+		}
+	}
+}
+
+// interpolateLyricUnits times every unmatched run of units by spreading it over the gap between the
+// matched units around it, in proportion to each unit's length. Runs at the edges extend to from and to.
+func interpolateLyricUnits(units []lyricUnit, from, to float64) {
+	for i := 0; i < len(units); {
+		if units[i].Matched {
+			i++
+			continue
+		}
+
+		j := i
+		weight := 0.0
+		for ; j < len(units) && !units[j].Matched; j++ {
+			weight += float64(max(utf8.RuneCountInString(units[j].Key), 1))
+		}
+
+		gapStart, gapEnd := from, to
+		if i > 0 {
+			gapStart = units[i-1].End
+		}
+		if j < len(units) {
+			gapEnd = units[j].Start
+		}
+		gapEnd = max(gapEnd, gapStart)
+
+		position := gapStart
+		for k := i; k < j; k++ {
+			units[k].Start = position
+			position += (gapEnd - gapStart) * float64(max(utf8.RuneCountInString(units[k].Key), 1)) / weight
+			units[k].End = position
+		}
+		i = j
+	}
+}
+
+// alignLyrics replaces the transcription's segments with the supplied lyrics, one segment per line,
+// timed by aligning the lyric words to the recognized words. Lines without a single matched word are
+// interpolated between their neighbours and marked Interpolated; their words get zero probability so
+// the heatmap shows them as uncertain.
+func alignLyrics(output *WhisperOutput, lines []lyricLine) error {
+	units := lyricUnits(lines)
+	recognized := recognizedUnits(output)
+	if len(recognized) == 0 {
+		return fmt.Errorf("%w: the transcription has no word timings", ErrLyricsNotAligned)
+	}
+
+	alignLyricUnits(units, recognized)
+
+	matched := 0
+	for _, unit := range units {
+		if unit.Matched {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return ErrLyricsNotAligned
+	}
+
+	interpolateLyricUnits(units, 0, recognized[len(recognized)-1].End)
+
+	segments := make([]Segment, len(lines))
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		segments[i] = Segment{Text: line.Text, Interpolated: true}
+		texts[i] = line.Text
+	}
+
+	for _, unit := range units {
+		segment := &segments[unit.Line]
+		if len(segment.Words) == 0 {
+			segment.Start = unit.Start
+		}
+		segment.End = unit.End
+		segment.Words = append(segment.Words, Word{Word: unit.Text, Start: unit.Start, End: unit.End, Probability: unit.Probability})
+		segment.Confidence += unit.Probability
+		if unit.Matched {
+			segment.Interpolated = false
+		}
+	}
+
+	for i := range segments {
+		if words := len(segments[i].Words); words > 0 {
+			segments[i].Confidence /= float64(words)
+		}
+	}
+
+	output.Segments = segments
+	output.Text = strings.Join(texts, " ")
+	success(fmt.Sprintf("Aligned %d lyric lines (%d of %d words matched)", len(lines), matched, len(units)))
+
+	for i, segment := range segments {
+		if segment.Interpolated {
+			warning(fmt.Sprintf("Line %d interpolated at %s, no words matched: %s", lines[i].Number, secondsToLRCTimestamp(segment.Start), segment.Text))
+		}
+	}
+	return nil
+}
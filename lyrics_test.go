@@ -0,0 +1,146 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// timedSegment builds a transcribed segment whose space-separated words last one second each from start.
+func timedSegment(start float64, text string) Segment {
+	var words []Word
+	for i, word := range strings.Fields(text) {
+		words = append(words, Word{Word: " " + word, Start: start + float64(i), End: start + float64(i+1), Probability: 0.9})
+	}
+	return Segment{Start: start, End: start + float64(len(words)), Text: text, Words: words}
+}
+
+// unitTiming is the expected outcome for one lyric unit.
+type unitTiming struct {
+	Key     string
+	Start   float64
+	End     float64
+	Matched bool
+}
+
+func TestAlignAndInterpolateLyricUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		lyrics   []string
+		segments []Segment
+		to       float64
+		want     []unitTiming
+	}{
+		{
+			name:     "misheard words still anchor",
+			lyrics:   []string{"I'm going to love you"},
+			segments: []Segment{timedSegment(0, "Im gonna love ya")},
+			to:       4,
+			want: []unitTiming{
+				{"im", 0, 1, true},
+				{"going", 1, 2, true}, // "gonna" is 0.6 similar
+				{"to", 2, 2, false},
+				{"love", 2, 3, true},
+				{"you", 3, 4, false}, // "ya" is too different and gets the time left over
+			},
+		},
+		{
+			name:     "skipped line is spread over the gap",
+			lyrics:   []string{"hello darkness", "my old friend", "ive come to talk"},
+			segments: []Segment{timedSegment(0, "hello darkness"), timedSegment(5, "ive come to talk")},
+			to:       9,
+			want: []unitTiming{
+				{"hello", 0, 1, true},
+				{"darkness", 1, 2, true},
+				{"my", 2, 2 + 3.0*2/11, false},
+				{"old", 2 + 3.0*2/11, 2 + 3.0*5/11, false},
+				{"friend", 2 + 3.0*5/11, 5, false},
+				{"ive", 5, 6, true},
+				{"come", 6, 7, true},
+				{"to", 7, 8, true},
+				{"talk", 8, 9, true},
+			},
+		},
+		{
+			name:     "unmatched leading and trailing lines extend to the edges",
+			lyrics:   []string{"intro line", "real words here", "outro"},
+			segments: []Segment{timedSegment(0, "yeah yeah"), timedSegment(10, "real words here"), timedSegment(20, "oh")},
+			to:       21,
+			want: []unitTiming{
+				{"intro", 0, 10.0 * 5 / 9, false},
+				{"line", 10.0 * 5 / 9, 10, false},
+				{"real", 10, 11, true},
+				{"words", 11, 12, true},
+				{"here", 12, 13, true},
+				{"outro", 13, 21, false},
+			},
+		},
+		{
+			name:     "CJK is aligned per character",
+			lyrics:   []string{"夢を見た"},
+			segments: []Segment{{Start: 0, End: 2, Words: []Word{{Word: "夢を", Start: 0, End: 1}, {Word: "観た", Start: 1, End: 2}}}},
+			to:       2,
+			want: []unitTiming{
+				{"夢", 0, 0.5, true},
+				{"を", 0.5, 1, true},
+				{"見", 1, 1.5, false},
+				{"た", 1.5, 2, true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var lines []lyricLine
+			for i, text := range test.lyrics {
+				lines = append(lines, lyricLine{Number: i + 1, Text: text})
+			}
+			units := lyricUnits(lines)
+
+			alignLyricUnits(units, recognizedUnits(&WhisperOutput{Segments: test.segments}))
+			interpolateLyricUnits(units, 0, test.to)
+
+			if len(units) != len(test.want) {
+				t.Fatalf("got %d units, want %d", len(units), len(test.want))
+			}
+			for i, want := range test.want {
+				unit := units[i]
+				got := unitTiming{unit.Key, unit.Start, unit.End, unit.Matched}
+				if got.Key != want.Key || got.Matched != want.Matched || math.Abs(got.Start-want.Start) > 1e-9 || math.Abs(got.End-want.End) > 1e-9 {
+					t.Errorf("unit %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAlignLyricsMarksInterpolatedLines(t *testing.T) {
+	output := &WhisperOutput{Segments: []Segment{timedSegment(0, "hello darkness"), timedSegment(5, "ive come to talk")}}
+	lines := []lyricLine{{1, "Hello darkness,"}, {2, "my old friend"}, {4, "I've come to talk"}}
+
+	if err := alignLyrics(output, lines); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Segments) != 3 {
+		t.Fatalf("got %d segments, want 3", len(output.Segments))
+	}
+	for i, interpolated := range []bool{false, true, false} {
+		if segment := output.Segments[i]; segment.Interpolated != interpolated {
+			t.Errorf("segment %d (%q) interpolated = %v, want %v", i, segment.Text, segment.Interpolated, interpolated)
+		}
+	}
+	if segment := output.Segments[1]; segment.Start != 2 || segment.End != 5 || segment.Confidence != 0 {
+		t.Errorf("interpolated line = %v-%v confidence %v, want 2-5 and 0", segment.Start, segment.End, segment.Confidence)
+	}
+	if words := output.Segments[2].Words; words[0].Word != " I've" || words[0].Start != 5 {
+		t.Errorf("lyric words keep their spelling and take the recognized timing, got %+v", words[0])
+	}
+}
+
+func TestAlignLyricsRequiresAMatch(t *testing.T) {
+	output := &WhisperOutput{Segments: []Segment{timedSegment(0, "completely different words")}}
+	if err := alignLyrics(output, []lyricLine{{1, "nothing alike here"}}); err == nil {
+		t.Error("alignLyrics succeeded without a single matched word")
+	}
+}
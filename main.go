@@ -6,24 +6,76 @@ import (
 )
 
 // main orchestrates the complete EchoWave audio transcription workflow from start to finish.
-// It parses command-line flags, validates all required dependencies are installed, processes
-// the input audio source (either downloading from YouTube or using a local file), and generates
-// the final transcription output. The function handles cleanup of temporary files through defer
-// statements and exits with appropriate error codes if any step fails. This is the primary
-// entry point that coordinates all other application components.
+// It parses command-line flags, validates the dependencies for the selected backend are installed, processes
+// the input audio source (downloading a URL or using a local file), and generates
+// the final transcription output. The convert subcommand bypasses the dependency check and Whisper
+// entirely, re-rendering existing JSON transcriptions instead, and the cache subcommand manages
+// cached transcriptions. The function handles cleanup of temporary files through defer statements
+// and exits with appropriate error codes if any step fails. This is the primary entry point that
+// coordinates all other application components.
 func main() {
 	config := parseFlags()
 
 	checkForUpdates()
 
-	if !checkAllDependencies() {
+	if config.Command == "convert" {
+		if err := runConvert(flag.Args(), config); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+
+	if config.Command == "cache" {
+		if err := runCache(config); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+
+	transcriber, err := findTranscriber(config.Backend)
+	if err != nil {
+		exitWithError(newError("select transcription backend", err))
+	}
+
+	if !checkAllDependencies(requiredDependencies(transcriber, config)) {
 		os.Exit(1)
 	}
 
 	input := flag.Arg(0)
 
-	audioPath, cleanup := processAudio(input, config)
+	if flag.NArg() > 1 || isBatchInput(input) {
+		if err := runBatch(flag.Args(), config); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+
+	if config.Playlist || isPlaylistURL(input) {
+		if err := runPlaylist(input, config); err != nil {
+			exitWithError(err)
+		}
+		return
+	}
+
+	if err := transcribeInput(input, config); err != nil {
+		exitWithError(err)
+	}
+}
+
+// transcribeInput runs the whole pipeline for one input: download or trim the audio, transcribe it
+// and write the outputs. Temporary files are removed before returning. A YouTube URL's t parameter
+// sets the start of this input only.
+func transcribeInput(input string, config *Config) error {
+	config, err := inputTimeRange(input, config)
+	if err != nil {
+		return newError("parse time range", err)
+	}
+
+	audioPath, metadataPath, cleanup, err := processAudio(input, config)
+	if err != nil {
+		return err
+	}
 	defer cleanup()
 
-	generateTranscription(audioPath, config)
+	return generateTranscription(audioPath, metadataPath, config)
 }
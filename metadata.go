@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TranscriptMetadata describes the track a transcription belongs to.
+// It is stored alongside Whisper's output so later conversions can reproduce LRC ID tags.
+type TranscriptMetadata struct {
+	Title    string  `json:"title,omitempty"`
+	Artist   string  `json:"artist,omitempty"`
+	Album    string  `json:"album,omitempty"`
+	Author   string  `json:"author,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+	Prompt   string  `json:"prompt,omitempty"`
+}
+
+// ytDLPInfo holds the subset of yt-dlp's --write-info-json output used for track metadata.
+// Music-specific fields (track, artist, album) are preferred over generic video fields when present.
+type ytDLPInfo struct {
+	Title    string  `json:"title"`
+	Track    string  `json:"track"`
+	Artist   string  `json:"artist"`
+	Creator  string  `json:"creator"`
+	Uploader string  `json:"uploader"`
+	Album    string  `json:"album"`
+	Composer string  `json:"composer"`
+	Duration float64 `json:"duration"`
+}
+
+// ffprobeOutput holds the subset of `ffprobe -show_format` JSON output used for track metadata.
+type ffprobeOutput struct {
+	Format struct {
+		Duration string            `json:"duration"`
+		Tags     map[string]string `json:"tags"`
+	} `json:"format"`
+}
+
+// firstNonEmpty returns the first argument that is not blank.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// readYTDLPInfo parses the info JSON yt-dlp writes next to a downloaded file.
+func readYTDLPInfo(infoPath string) (*TranscriptMetadata, error) {
+	data, err := os.ReadFile(infoPath)
+	if err != nil {
+		return nil, newError("read yt-dlp info JSON", err)
+	}
+
+	var info ytDLPInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, newError("parse yt-dlp info JSON", err)
+	}
+
+	return &TranscriptMetadata{
+		Title:    firstNonEmpty(info.Track, info.Title),
+		Artist:   firstNonEmpty(info.Artist, info.Creator, info.Uploader),
+		Album:    info.Album,
+		Author:   info.Composer,
+		Duration: info.Duration,
+	}, nil
+}
+
+// readFFprobeMetadata reads container tags and duration from a local media file using ffprobe,
+// which ships with the ffmpeg dependency. Tag names are matched case-insensitively.
+func readFFprobeMetadata(audioPath string) (*TranscriptMetadata, error) {
+	cmd := exec.Command("ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", audioPath)
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, newError("run ffprobe", err)
+	}
+
+	var probe ffprobeOutput
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, newError("parse ffprobe output", err)
+	}
+
+	tags := make(map[string]string, len(probe.Format.Tags))
+	for key, value := range probe.Format.Tags {
+		tags[strings.ToLower(key)] = value
+	}
+
+	duration, _ := strconv.ParseFloat(probe.Format.Duration, 64)
+
+	return &TranscriptMetadata{
+		Title:    tags["title"],
+		Artist:   firstNonEmpty(tags["artist"], tags["album_artist"]),
+		Album:    tags["album"],
+		Author:   tags["composer"],
+		Duration: duration,
+	}, nil
+}
+
+// readTrackMetadata gathers title, artist, album and duration for an audio file.
+// A yt-dlp info JSON next to the file (written during yt-dlp downloads) takes priority;
+// otherwise ffprobe is used. Metadata is best effort, so failures only produce a warning.
+func readTrackMetadata(audioPath string) *TranscriptMetadata {
+	infoPath := strings.TrimSuffix(audioPath, filepath.Ext(audioPath)) + ".info.json"
+	if _, err := os.Stat(infoPath); err == nil {
+		metadata, err := readYTDLPInfo(infoPath)
+		if err == nil {
+			return metadata
+		}
+		warning("Failed to read download metadata: " + err.Error())
+	}
+
+	metadata, err := readFFprobeMetadata(audioPath)
+	if err != nil {
+		warning("Failed to read audio metadata: " + err.Error())
+		return &TranscriptMetadata{}
+	}
+	return metadata
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	apiBaseURLEnv      = "ECHOWAVE_API_BASE_URL"
+	apiKeyEnv          = "ECHOWAVE_API_KEY"
+	openAIBaseURLEnv   = "OPENAI_BASE_URL"
+	openAIKeyEnv       = "OPENAI_API_KEY"
+	defaultAPIBaseURL  = "https://api.openai.com/v1"
+	apiRequestTimeout  = 30 * time.Minute
+	apiErrorBodyLimit  = 512
+	transcriptionsPath = "/audio/transcriptions"
+	translationsPath   = "/audio/translations"
+)
+
+var ErrTranscriptionAPI = errors.New("transcription API request failed")
+
+// verboseTranscription is the verbose_json response of an OpenAI-compatible /audio/transcriptions endpoint.
+// Words are reported at the top level, separately from segments, and carry no probability.
+type verboseTranscription struct {
+	Language string    `json:"language"`
+	Text     string    `json:"text"`
+	Segments []Segment `json:"segments"`
+	Words    []struct {
+		Word  string  `json:"word"`
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+	} `json:"words"`
+}
+
+// openAITranscriber uploads audio to an OpenAI-compatible HTTP transcription server.
+type openAITranscriber struct{}
+
+func (openAITranscriber) Name() string {
+	return "openai"
+}
+
+// Dependencies is empty because the HTTP backend needs no local transcription binary.
+func (openAITranscriber) Dependencies(_ *Config) []Dependency {
+	return nil
+}
+
+// CacheKey identifies the server by its resolved base URL, including one set through the environment.
+func (openAITranscriber) CacheKey(config *Config) []string {
+	return []string{resolveAPIBaseURL(config)}
+}
+
+// resolveAPIBaseURL returns the server base URL from -api-base-url, $ECHOWAVE_API_BASE_URL,
+// $OPENAI_BASE_URL or the public OpenAI endpoint, in that order, without a trailing slash.
+func resolveAPIBaseURL(config *Config) string {
+	return strings.TrimRight(firstNonEmpty(config.APIBaseURL, os.Getenv(apiBaseURLEnv), os.Getenv(openAIBaseURLEnv), defaultAPIBaseURL), "/")
+}
+
+// resolveAPIKey returns the API key from -api-key, $ECHOWAVE_API_KEY or $OPENAI_API_KEY.
+// An empty key is allowed so local servers without authentication work.
+func resolveAPIKey(config *Config) string {
+	return firstNonEmpty(config.APIKey, os.Getenv(apiKeyEnv), os.Getenv(openAIKeyEnv))
+}
+
+// transcriptionRequestBody builds the multipart request body: the form fields followed by the audio file.
+// The file is streamed from disk rather than buffered, and the exact body length is returned so the request
+// is sent with a Content-Length header, which simple self-hosted servers require instead of chunked encoding.
+func transcriptionRequestBody(audioPath string, fields [][2]string) (io.Reader, int64, string, func(), error) {
+	audioFile, err := os.Open(audioPath)
+	if err != nil {
+		return nil, 0, "", nil, err
+	}
+	stat, err := audioFile.Stat()
+	if err != nil {
+		audioFile.Close()
+		return nil, 0, "", nil, err
+	}
+
+	var head bytes.Buffer
+	form := multipart.NewWriter(&head)
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			audioFile.Close()
+			return nil, 0, "", nil, err
+		}
+	}
+	if _, err := form.CreateFormFile("file", filepath.Base(audioPath)); err != nil {
+		audioFile.Close()
+		return nil, 0, "", nil, err
+	}
+	headLength := head.Len()
+
+	if err := form.Close(); err != nil {
+		audioFile.Close()
+		return nil, 0, "", nil, err
+	}
+	tail := append([]byte(nil), head.Bytes()[headLength:]...)
+	head.Truncate(headLength)
+
+	body := io.MultiReader(&head, audioFile, bytes.NewReader(tail))
+	length := int64(headLength) + stat.Size() + int64(len(tail))
+	return body, length, form.FormDataContentType(), func() { audioFile.Close() }, nil
+}
+
+// convertVerboseTranscription maps a verbose_json response onto WhisperOutput.
+// Top-level words are assigned to the segment whose time range contains their start; since the API
+// reports no word probability, each word inherits its segment's exp(avg_logprob) for the heatmap.
+// When the server returns words but no segments, all words are placed in a single segment.
+func convertVerboseTranscription(response *verboseTranscription) *WhisperOutput {
+	output := &WhisperOutput{Text: response.Text, Language: response.Language, Segments: response.Segments}
+
+	if len(output.Segments) == 0 && len(response.Words) > 0 {
+		last := response.Words[len(response.Words)-1]
+		output.Segments = []Segment{{Start: response.Words[0].Start, End: last.End, Text: response.Text}}
+	}
+
+	for i := range output.Segments {
+		output.Segments[i].Words = nil
+	}
+
+	for _, word := range response.Words {
+		index := len(output.Segments) - 1
+		for i, segment := range output.Segments {
+			if word.Start < segment.End || i == len(output.Segments)-1 {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			continue
+		}
+
+		segment := &output.Segments[index]
+		probability := 1.0
+		if segment.AvgLogprob != 0 {
+			probability = math.Exp(segment.AvgLogprob)
+		}
+		segment.Words = append(segment.Words, Word{
+			Word:        " " + strings.TrimSpace(word.Word),
+			Start:       word.Start,
+			End:         word.End,
+			Probability: probability,
+		})
+	}
+
+	return output
+}
+
+// Transcribe uploads the audio with verbose_json and converts the response. Transcriptions ask for word and
+// segment timestamp granularity; the translate task uses the /audio/translations endpoint, which always
+// returns English, accepts no timestamp_granularities and so yields segments without word timings.
+func (openAITranscriber) Transcribe(audioPath string, config *Config) (*WhisperOutput, error) {
+	endpoint := resolveAPIBaseURL(config) + transcriptionsPath
+	if config.Task == taskTranslate {
+		endpoint = resolveAPIBaseURL(config) + translationsPath
+	}
+	processing("Uploading audio to transcription server...")
+	step("Endpoint: " + endpoint + ", Model: " + config.Model + ", Language: " + config.Language)
+
+	fields := [][2]string{
+		{"model", config.Model},
+		{"response_format", "verbose_json"},
+		{"temperature", "0"},
+	}
+	if config.Task != taskTranslate {
+		fields = append(fields, [2]string{"timestamp_granularities[]", "word"}, [2]string{"timestamp_granularities[]", "segment"})
+		if config.Language != autoLanguage {
+			fields = append(fields, [2]string{"language", config.Language})
+		}
+	}
+	if config.Prompt != "" {
+		fields = append(fields, [2]string{"prompt", config.Prompt})
+	}
+
+	body, length, contentType, closeBody, err := transcriptionRequestBody(audioPath, fields)
+	if err != nil {
+		return nil, newError("read audio file", err)
+	}
+	defer closeBody()
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, newError("create transcription request", err)
+	}
+	req.ContentLength = length
+	req.Header.Set("Content-Type", contentType)
+	if key := resolveAPIKey(config); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, newError("upload audio for transcription", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorBody, _ := io.ReadAll(io.LimitReader(resp.Body, apiErrorBodyLimit))
+		return nil, newError("transcribe audio", fmt.Errorf("%w: status %d: %s", ErrTranscriptionAPI, resp.StatusCode, strings.TrimSpace(string(errorBody))))
+	}
+
+	var response verboseTranscription
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, newError("parse transcription response", err)
+	}
+
+	output := convertVerboseTranscription(&response)
+	if len(output.Segments) == 0 {
+		return nil, newError("process transcription", ErrNoSegmentsFound)
+	}
+
+	success("Server transcription completed")
+	return output, nil
+}
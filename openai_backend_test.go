@@ -0,0 +1,173 @@
+package main
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const verboseJSONResponse = `{
+	"task": "transcribe",
+	"language": "english",
+	"duration": 4.0,
+	"text": "Hello world. Second line.",
+	"segments": [
+		{"id": 0, "seek": 0, "start": 0.0, "end": 2.0, "text": " Hello world.", "tokens": [1, 2], "temperature": 0.0,
+		 "avg_logprob": -0.5, "compression_ratio": 1.0, "no_speech_prob": 0.01},
+		{"id": 1, "seek": 0, "start": 2.0, "end": 4.0, "text": " Second line.", "tokens": [3], "temperature": 0.0,
+		 "avg_logprob": 0, "compression_ratio": 1.0, "no_speech_prob": 0.02}
+	],
+	"words": [
+		{"word": "Hello", "start": 0.0, "end": 0.8},
+		{"word": "world.", "start": 0.8, "end": 1.9},
+		{"word": "Second", "start": 2.1, "end": 2.9},
+		{"word": "line.", "start": 2.9, "end": 3.8}
+	]
+}`
+
+// transcriptionRequest is what the fake server received in one multipart upload.
+type transcriptionRequest struct {
+	path          string
+	authorization string
+	fields        map[string][]string
+	fileName      string
+	fileContent   string
+}
+
+// newTranscriptionServer starts a fake OpenAI-compatible server that records each request and answers with response.
+func newTranscriptionServer(t *testing.T, response string) (*httptest.Server, *[]transcriptionRequest) {
+	t.Helper()
+	var requests []transcriptionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength <= 0 {
+			t.Errorf("request sent without Content-Length")
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse multipart form: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request := transcriptionRequest{path: r.URL.Path, authorization: r.Header.Get("Authorization"), fields: r.MultipartForm.Value}
+		if file, header, err := r.FormFile("file"); err == nil {
+			content, _ := io.ReadAll(file)
+			file.Close()
+			request.fileName, request.fileContent = header.Filename, string(content)
+		}
+		requests = append(requests, request)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// writeTestAudio creates a small stand-in audio file for upload tests.
+func writeTestAudio(t *testing.T) string {
+	t.Helper()
+	audioPath := filepath.Join(t.TempDir(), "song.mp3")
+	if err := os.WriteFile(audioPath, []byte("not really audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return audioPath
+}
+
+func TestOpenAITranscribeSendsMultipartFields(t *testing.T) {
+	server, requests := newTranscriptionServer(t, verboseJSONResponse)
+	audioPath := writeTestAudio(t)
+	config := &Config{APIBaseURL: server.URL + "/v1/", APIKey: "test-key", Model: "whisper-1", Language: "en", Task: taskTranscribe, Prompt: "Song lyrics."}
+
+	if _, err := (openAITranscriber{}).Transcribe(audioPath, config); err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*requests))
+	}
+	request := (*requests)[0]
+	if request.path != "/v1"+transcriptionsPath {
+		t.Errorf("path = %q", request.path)
+	}
+	if request.authorization != "Bearer test-key" {
+		t.Errorf("Authorization = %q", request.authorization)
+	}
+	want := map[string][]string{
+		"model":                     {"whisper-1"},
+		"response_format":           {"verbose_json"},
+		"temperature":               {"0"},
+		"timestamp_granularities[]": {"word", "segment"},
+		"language":                  {"en"},
+		"prompt":                    {"Song lyrics."},
+	}
+	for name, values := range want {
+		if got := request.fields[name]; !slices.Equal(got, values) {
+			t.Errorf("field %s = %q, want %q", name, got, values)
+		}
+	}
+	if len(request.fields) != len(want) {
+		t.Errorf("fields = %v, want exactly %v", request.fields, want)
+	}
+	if request.fileName != "song.mp3" || request.fileContent != "not really audio" {
+		t.Errorf("file = %q %q", request.fileName, request.fileContent)
+	}
+}
+
+func TestOpenAITranslateOmitsTimestampGranularities(t *testing.T) {
+	server, requests := newTranscriptionServer(t, verboseJSONResponse)
+	config := &Config{APIBaseURL: server.URL, Model: "whisper-1", Language: "ja", Task: taskTranslate}
+
+	if _, err := (openAITranscriber{}).Transcribe(writeTestAudio(t), config); err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+
+	request := (*requests)[0]
+	if request.path != translationsPath {
+		t.Errorf("path = %q, want %q", request.path, translationsPath)
+	}
+	for _, name := range []string{"timestamp_granularities[]", "language"} {
+		if values, ok := request.fields[name]; ok {
+			t.Errorf("translation request sent %s = %q", name, values)
+		}
+	}
+}
+
+func TestOpenAITranscribeMapsVerboseJSON(t *testing.T) {
+	server, _ := newTranscriptionServer(t, verboseJSONResponse)
+	config := &Config{APIBaseURL: server.URL, Model: "whisper-1", Language: autoLanguage, Task: taskTranscribe}
+
+	output, err := (openAITranscriber{}).Transcribe(writeTestAudio(t), config)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+
+	if output.Language != "english" || output.Text != "Hello world. Second line." {
+		t.Errorf("output = %q %q", output.Language, output.Text)
+	}
+	if len(output.Segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(output.Segments))
+	}
+
+	first, second := output.Segments[0], output.Segments[1]
+	if first.Text != " Hello world." || first.Start != 0 || first.End != 2 || first.AvgLogprob != -0.5 {
+		t.Errorf("first segment = %+v", first)
+	}
+	if len(first.Words) != 2 || len(second.Words) != 2 {
+		t.Fatalf("words per segment = %d, %d, want 2, 2", len(first.Words), len(second.Words))
+	}
+	if word := first.Words[1]; word.Word != " world." || word.Start != 0.8 || word.End != 1.9 {
+		t.Errorf("word = %+v", word)
+	}
+	if probability := first.Words[0].Probability; math.Abs(probability-math.Exp(-0.5)) > 1e-9 {
+		t.Errorf("word probability = %v, want exp(avg_logprob)", probability)
+	}
+	if probability := second.Words[0].Probability; probability != 1 {
+		t.Errorf("word probability without avg_logprob = %v, want 1", probability)
+	}
+	if string(first.Raw["tokens"]) != "[1, 2]" {
+		t.Errorf("segment tokens = %s, want them kept", first.Raw["tokens"])
+	}
+}
@@ -0,0 +1,960 @@
+package main
+
+// pinyinReadings lists common Simplified and Traditional Chinese characters by their Hanyu Pinyin reading.
+// Characters with several readings appear once, under the reading most common in song lyrics
+// (for example 了 le, 着 zhe, 长 cháng); characters not listed are left unchanged by romanizeChinese.
+var pinyinReadings = map[string]string{
+	"ā":      "阿",
+	"a":      "啊",
+	"āi":     "哀埃挨唉哎",
+	"ái":     "癌",
+	"ǎi":     "矮",
+	"ài":     "爱愛碍礙艾",
+	"ān":     "安鞍",
+	"àn":     "暗按案岸",
+	"áng":    "昂",
+	"áo":     "熬",
+	"ào":     "奥奧傲",
+	"bā":     "八巴扒疤芭",
+	"bá":     "拔跋",
+	"bǎ":     "把靶",
+	"bà":     "爸霸罢罷坝",
+	"ba":     "吧",
+	"bái":    "白",
+	"bǎi":    "百摆擺柏",
+	"bài":    "败敗拜",
+	"bān":    "班般搬斑颁",
+	"bǎn":    "板版",
+	"bàn":    "半办辦伴扮瓣拌",
+	"bāng":   "帮幫邦",
+	"bǎng":   "绑綁榜膀",
+	"bàng":   "棒傍磅谤",
+	"bāo":    "包胞剥",
+	"báo":    "雹",
+	"bǎo":    "宝寶保饱飽堡",
+	"bào":    "报報抱暴爆豹",
+	"bēi":    "杯悲碑卑",
+	"běi":    "北",
+	"bèi":    "被倍备備贝貝辈輩背",
+	"bēn":    "奔",
+	"běn":    "本",
+	"bèn":    "笨",
+	"bēng":   "崩绷",
+	"bèng":   "蹦",
+	"bī":     "逼",
+	"bí":     "鼻",
+	"bǐ":     "比笔筆彼鄙",
+	"bì":     "必毕畢闭閉避壁臂碧币幣弊蔽",
+	"biān":   "边邊编編鞭",
+	"biǎn":   "扁",
+	"biàn":   "变變便遍辨辩辯",
+	"biāo":   "标標",
+	"biǎo":   "表錶",
+	"bié":    "别別",
+	"bīn":    "宾賓滨濱",
+	"bīng":   "冰兵",
+	"bǐng":   "饼餅丙柄",
+	"bìng":   "并並病",
+	"bō":     "波播拨撥玻",
+	"bó":     "博伯脖薄勃泊",
+	"bǔ":     "补補捕",
+	"bù":     "不布步部怖",
+	"cā":     "擦",
+	"cāi":    "猜",
+	"cái":    "才材财財裁",
+	"cǎi":    "彩采踩睬",
+	"cài":    "菜",
+	"cān":    "参參餐",
+	"cán":    "残殘蚕",
+	"cǎn":    "惨慘",
+	"càn":    "灿燦",
+	"cāng":   "仓倉苍蒼沧滄舱",
+	"cáng":   "藏",
+	"cāo":    "操糙",
+	"cǎo":    "草",
+	"cè":     "册策侧側测測厕",
+	"céng":   "层層曾",
+	"cèng":   "蹭",
+	"chā":    "插叉",
+	"chá":    "茶查察",
+	"chà":    "差岔诧",
+	"chāi":   "拆",
+	"chái":   "柴",
+	"chán":   "缠纏蝉蟬馋",
+	"chǎn":   "产產铲",
+	"chàn":   "颤顫",
+	"chāng":  "昌",
+	"cháng":  "长長常尝嘗偿償肠腸",
+	"chǎng":  "场場厂廠敞",
+	"chàng":  "唱畅暢倡",
+	"chāo":   "超抄钞",
+	"cháo":   "朝潮巢嘲",
+	"chǎo":   "吵炒",
+	"chē":    "车車",
+	"chě":    "扯",
+	"chè":    "彻徹撤",
+	"chén":   "沉尘塵晨陈陳臣辰",
+	"chèn":   "趁衬襯",
+	"chēng":  "称稱撑",
+	"chéng":  "成城诚誠承乘程呈橙",
+	"chèng":  "秤",
+	"chī":    "吃痴",
+	"chí":    "迟遲持池驰",
+	"chǐ":    "尺齿齒耻恥",
+	"chì":    "赤翅斥",
+	"chōng":  "冲衝充",
+	"chóng":  "虫蟲崇",
+	"chǒng":  "宠寵",
+	"chōu":   "抽",
+	"chóu":   "愁仇绸酬",
+	"chǒu":   "丑醜",
+	"chòu":   "臭",
+	"chū":    "出初",
+	"chú":    "除厨廚锄",
+	"chǔ":    "楚础礎储儲",
+	"chù":    "处處触觸",
+	"chuān":  "穿川",
+	"chuán":  "船传傳",
+	"chuǎn":  "喘",
+	"chuāng": "窗疮",
+	"chuáng": "床",
+	"chuǎng": "闯闖",
+	"chuàng": "创創",
+	"chuī":   "吹炊",
+	"chuí":   "垂锤",
+	"chūn":   "春",
+	"chún":   "纯純唇",
+	"chǔn":   "蠢",
+	"cí":     "词詞辞辭磁慈瓷雌",
+	"cǐ":     "此",
+	"cì":     "次刺赐",
+	"cōng":   "匆聪聰葱",
+	"cóng":   "从從丛叢",
+	"còu":    "凑",
+	"cū":     "粗",
+	"cù":     "促醋",
+	"cuī":    "催摧",
+	"cuì":    "脆翠",
+	"cūn":    "村",
+	"cún":    "存",
+	"cùn":    "寸",
+	"cuò":    "错錯措挫",
+	"dā":     "搭",
+	"dá":     "达達答",
+	"dǎ":     "打",
+	"dà":     "大",
+	"dāi":    "呆",
+	"dài":    "代带帶待袋戴贷",
+	"dān":    "单單担擔丹耽",
+	"dǎn":    "胆膽",
+	"dàn":    "但淡蛋诞",
+	"dāng":   "当當",
+	"dǎng":   "挡擋党黨",
+	"dàng":   "荡蕩档",
+	"dāo":    "刀",
+	"dǎo":    "倒岛島导導蹈",
+	"dào":    "到道盗稻",
+	"dé":     "得德",
+	"de":     "的",
+	"dēng":   "灯燈登蹬",
+	"děng":   "等",
+	"dèng":   "瞪邓",
+	"dī":     "低滴堤",
+	"dí":     "敌敵笛",
+	"dǐ":     "底抵",
+	"dì":     "地第弟帝递遞",
+	"diǎn":   "点點典",
+	"diàn":   "电電店殿垫",
+	"diāo":   "雕凋叼",
+	"diào":   "掉钓",
+	"diē":    "跌爹",
+	"dié":    "叠蝶",
+	"dīng":   "丁盯钉",
+	"dǐng":   "顶頂",
+	"dìng":   "定订",
+	"diū":    "丢丟",
+	"dōng":   "东東冬",
+	"dǒng":   "懂",
+	"dòng":   "动動冻凍洞",
+	"dōu":    "都兜",
+	"dǒu":    "抖",
+	"dòu":    "豆逗斗",
+	"dú":     "读讀独獨毒",
+	"dǔ":     "堵赌睹",
+	"dù":     "度渡肚妒",
+	"duān":   "端",
+	"duǎn":   "短",
+	"duàn":   "断斷段锻",
+	"duī":    "堆",
+	"duì":    "对對队隊",
+	"dūn":    "蹲吨",
+	"dùn":    "顿頓盾",
+	"duō":    "多",
+	"duó":    "夺奪",
+	"duǒ":    "朵躲",
+	"é":      "鹅额額俄",
+	"è":      "饿餓恶惡",
+	"ēn":     "恩",
+	"ér":     "儿兒而",
+	"ěr":     "耳尔爾",
+	"èr":     "二",
+	"fā":     "发發",
+	"fá":     "乏罚",
+	"fǎ":     "法",
+	"fà":     "髮",
+	"fān":    "翻番帆",
+	"fán":    "凡烦煩繁",
+	"fǎn":    "反返",
+	"fàn":    "饭飯犯范泛",
+	"fāng":   "方芳",
+	"fáng":   "房防妨",
+	"fǎng":   "仿访訪",
+	"fàng":   "放",
+	"fēi":    "飞飛非菲啡",
+	"féi":    "肥",
+	"fěi":    "匪",
+	"fèi":    "费費废廢肺沸",
+	"fēn":    "分纷紛芬",
+	"fěn":    "粉",
+	"fèn":    "份奋奮愤憤",
+	"fēng":   "风風丰豐封疯瘋峰锋蜂",
+	"féng":   "逢缝",
+	"fěng":   "讽",
+	"fèng":   "凤鳳奉",
+	"fó":     "佛",
+	"fǒu":    "否",
+	"fū":     "夫肤膚孵",
+	"fú":     "福服扶浮符幅伏",
+	"fǔ":     "府腐抚辅",
+	"fù":     "父付负負富复復副妇婦附赴覆",
+	"gāi":    "该該",
+	"gǎi":    "改",
+	"gài":    "盖蓋概",
+	"gān":    "甘肝杆乾",
+	"gǎn":    "感敢赶趕",
+	"gàn":    "干幹",
+	"gāng":   "刚剛钢鋼缸",
+	"gǎng":   "港岗",
+	"gāo":    "高糕",
+	"gǎo":    "搞稿",
+	"gào":    "告",
+	"gē":     "歌哥割鸽胳",
+	"gé":     "格隔革阁",
+	"gè":     "个個各",
+	"gěi":    "给給",
+	"gēn":    "跟根",
+	"gēng":   "耕",
+	"gèng":   "更",
+	"gōng":   "工公功攻宫宮弓恭",
+	"gǒng":   "巩拱",
+	"gòng":   "共贡",
+	"gōu":    "沟溝钩勾",
+	"gǒu":    "狗",
+	"gòu":    "够夠构購购",
+	"gū":     "孤姑估",
+	"gǔ":     "古鼓骨谷股",
+	"gù":     "故固顾顧雇",
+	"guā":    "瓜刮",
+	"guà":    "挂掛",
+	"guāi":   "乖",
+	"guǎi":   "拐",
+	"guài":   "怪",
+	"guān":   "关關观觀官冠",
+	"guǎn":   "管馆館",
+	"guàn":   "惯慣灌贯罐",
+	"guāng":  "光",
+	"guǎng":  "广廣",
+	"guàng":  "逛",
+	"guī":    "归歸规規龟",
+	"guǐ":    "鬼轨",
+	"guì":    "贵貴跪柜",
+	"gǔn":    "滚滾",
+	"guō":    "锅鍋郭",
+	"guó":    "国國",
+	"guǒ":    "果裹",
+	"guò":    "过過",
+	"hā":     "哈",
+	"hāi":    "嗨",
+	"hái":    "还還孩",
+	"hǎi":    "海",
+	"hài":    "害",
+	"hán":    "寒含韩韓",
+	"hǎn":    "喊罕",
+	"hàn":    "汗汉漢旱憾",
+	"háng":   "航",
+	"hǎo":    "好",
+	"hào":    "号號浩耗",
+	"hē":     "喝",
+	"hé":     "和何合河盒核荷",
+	"hè":     "贺賀鹤",
+	"hēi":    "黑嘿",
+	"hěn":    "很狠",
+	"hèn":    "恨",
+	"héng":   "横恒",
+	"hōng":   "轰",
+	"hóng":   "红紅虹洪宏",
+	"hóu":    "喉猴",
+	"hǒu":    "吼",
+	"hòu":    "后後候厚",
+	"hū":     "呼忽乎",
+	"hú":     "湖胡壶蝴糊狐",
+	"hǔ":     "虎",
+	"hù":     "户护護互",
+	"huā":    "花",
+	"huá":    "华華滑划",
+	"huà":    "话話画畫化",
+	"huái":   "怀懷",
+	"huài":   "坏壞",
+	"huān":   "欢歡",
+	"huán":   "环環",
+	"huǎn":   "缓",
+	"huàn":   "换換幻患唤",
+	"huāng":  "荒慌",
+	"huáng":  "黄黃皇",
+	"huǎng":  "谎謊晃",
+	"huī":    "灰挥揮辉輝恢",
+	"huí":    "回",
+	"huǐ":    "毁悔",
+	"huì":    "会會惠汇绘繪慧",
+	"hūn":    "昏婚",
+	"hún":    "魂浑",
+	"hùn":    "混",
+	"huó":    "活",
+	"huǒ":    "火伙",
+	"huò":    "或货貨获獲祸禍惑",
+	"jī":     "机機基激鸡雞积積击擊饥肌姬",
+	"jí":     "及即极極急集级級吉疾籍",
+	"jǐ":     "几幾己挤",
+	"jì":     "记記计計继繼纪紀季寄技际際既忌寂绩祭迹跡",
+	"jiā":    "家加佳夹",
+	"jiǎ":    "假甲",
+	"jià":    "价價架驾嫁",
+	"jiān":   "间間肩坚堅尖艰艱监兼煎",
+	"jiǎn":   "简簡减減剪检檢捡",
+	"jiàn":   "见見件建健渐漸剑劍箭践",
+	"jiāng":  "将將江姜疆",
+	"jiǎng":  "讲講奖獎",
+	"jiàng":  "降酱",
+	"jiāo":   "交焦骄娇郊胶",
+	"jiǎo":   "脚腳角饺",
+	"jiào":   "叫教较",
+	"jiē":    "接街阶皆揭",
+	"jié":    "结結节節杰洁潔劫捷",
+	"jiě":    "姐解",
+	"jiè":    "界借介届戒",
+	"jīn":    "金今斤巾津",
+	"jǐn":    "紧緊仅僅锦",
+	"jìn":    "进進近尽盡禁劲",
+	"jīng":   "经經京惊驚精晶睛鲸",
+	"jǐng":   "井景警",
+	"jìng":   "静靜镜鏡境竟敬径净淨",
+	"jiǒng":  "窘",
+	"jiū":    "究揪纠",
+	"jiǔ":    "九久酒",
+	"jiù":    "就旧舊救",
+	"jū":     "居拘",
+	"jú":     "局菊",
+	"jǔ":     "举舉",
+	"jù":     "句据據距剧劇聚巨具拒俱",
+	"juān":   "捐",
+	"juǎn":   "卷捲",
+	"juàn":   "倦眷",
+	"jué":    "觉覺决決绝絕",
+	"jūn":    "军軍君均",
+	"jùn":    "俊",
+	"kā":     "咖",
+	"kǎ":     "卡",
+	"kāi":    "开開",
+	"kǎi":    "凯",
+	"kān":    "刊",
+	"kǎn":    "砍坎",
+	"kàn":    "看",
+	"kāng":   "康",
+	"káng":   "扛",
+	"kàng":   "抗",
+	"kǎo":    "考烤",
+	"kào":    "靠",
+	"kē":     "科棵颗顆磕",
+	"ké":     "咳壳",
+	"kě":     "可渴",
+	"kè":     "课課刻客克",
+	"kěn":    "肯恳",
+	"kōng":   "空",
+	"kǒng":   "孔恐",
+	"kòng":   "控",
+	"kǒu":    "口",
+	"kòu":    "扣",
+	"kū":     "哭枯",
+	"kǔ":     "苦",
+	"kù":     "酷裤库",
+	"kuā":    "夸",
+	"kuà":    "跨",
+	"kuài":   "快块塊筷",
+	"kuān":   "宽寬",
+	"kuáng":  "狂",
+	"kuàng":  "况況矿框旷",
+	"kuī":    "亏虧",
+	"kuì":    "愧",
+	"kùn":    "困睏",
+	"kuò":    "扩阔闊括",
+	"lā":     "拉",
+	"là":     "辣蜡",
+	"la":     "啦",
+	"lái":    "来來",
+	"lài":    "赖賴",
+	"lán":    "蓝藍兰蘭拦栏",
+	"lǎn":    "懒懶览",
+	"làn":    "烂爛滥",
+	"láng":   "狼郎廊",
+	"lǎng":   "朗",
+	"làng":   "浪",
+	"lāo":    "捞",
+	"láo":    "劳勞牢",
+	"lǎo":    "老",
+	"lè":     "乐樂勒",
+	"le":     "了",
+	"léi":    "雷",
+	"lèi":    "泪淚累类類",
+	"lěng":   "冷",
+	"lí":     "离離梨黎璃",
+	"lǐ":     "里裡裏理李礼禮",
+	"lì":     "力立利丽麗历歷例粒厉",
+	"liǎ":    "俩",
+	"lián":   "连連怜憐莲蓮联聯帘",
+	"liǎn":   "脸臉",
+	"liàn":   "恋戀练練炼链",
+	"liáng":  "凉涼良粮",
+	"liǎng":  "两兩",
+	"liàng":  "亮量谅辆",
+	"liáo":   "聊疗辽",
+	"liào":   "料",
+	"liè":    "列烈裂猎",
+	"lín":    "林临臨邻鄰淋",
+	"líng":   "零灵靈铃玲龄凌",
+	"lǐng":   "领領岭",
+	"lìng":   "另令",
+	"liū":    "溜",
+	"liú":    "流留刘劉",
+	"liǔ":    "柳",
+	"liù":    "六",
+	"lóng":   "龙龍笼聋",
+	"lóu":    "楼樓",
+	"lòu":    "漏",
+	"lú":     "炉",
+	"lǔ":     "鲁",
+	"lù":     "路露陆陸录錄鹿",
+	"lǘ":     "驴",
+	"lǚ":     "旅",
+	"lǜ":     "绿綠律虑慮",
+	"luàn":   "乱亂",
+	"lüè":    "略",
+	"lùn":    "论論",
+	"lún":    "轮輪",
+	"luó":    "罗羅逻螺",
+	"luò":    "落洛骆",
+	"mā":     "妈媽",
+	"mǎ":     "马馬码",
+	"mà":     "骂罵",
+	"ma":     "吗嗎嘛",
+	"mái":    "埋",
+	"mǎi":    "买買",
+	"mài":    "卖賣麦麥迈",
+	"mǎn":    "满滿",
+	"màn":    "慢漫蔓",
+	"máng":   "忙盲茫",
+	"māo":    "猫貓",
+	"máo":    "毛矛",
+	"mào":    "冒帽貌茂",
+	"me":     "么麼",
+	"méi":    "没沒眉梅媒煤玫",
+	"měi":    "美每",
+	"mèi":    "妹魅昧",
+	"mén":    "门門",
+	"mèn":    "闷悶",
+	"men":    "们們",
+	"méng":   "蒙萌盟",
+	"měng":   "猛",
+	"mèng":   "梦夢孟",
+	"mí":     "迷谜謎弥",
+	"mǐ":     "米",
+	"mì":     "密秘蜜觅",
+	"mián":   "眠棉绵綿",
+	"miǎn":   "免勉",
+	"miàn":   "面麵",
+	"miáo":   "苗描",
+	"miǎo":   "秒渺",
+	"miào":   "妙庙",
+	"miè":    "灭滅",
+	"mín":    "民",
+	"mǐn":    "敏",
+	"míng":   "明名鸣鳴铭",
+	"mìng":   "命",
+	"mō":     "摸",
+	"mó":     "魔模磨膜",
+	"mǒ":     "抹",
+	"mò":     "末莫默寞墨漠陌沫",
+	"móu":    "谋",
+	"mǒu":    "某",
+	"mǔ":     "母亩",
+	"mù":     "木目幕慕墓暮牧",
+	"ná":     "拿",
+	"nǎ":     "哪",
+	"nà":     "那纳",
+	"nǎi":    "奶乃",
+	"nài":    "耐奈",
+	"nán":    "男南难難",
+	"nǎo":    "脑腦恼惱",
+	"nào":    "闹鬧",
+	"ne":     "呢",
+	"nèi":    "内內",
+	"nèn":    "嫩",
+	"néng":   "能",
+	"ní":     "泥",
+	"nǐ":     "你妳拟",
+	"nì":     "逆溺腻",
+	"nián":   "年",
+	"niàn":   "念",
+	"niáng":  "娘",
+	"niǎo":   "鸟鳥",
+	"niē":    "捏",
+	"nín":    "您",
+	"níng":   "宁寧凝",
+	"niú":    "牛",
+	"niǔ":    "扭纽",
+	"nóng":   "农農浓濃",
+	"nòng":   "弄",
+	"nú":     "奴",
+	"nǔ":     "努",
+	"nù":     "怒",
+	"nǚ":     "女",
+	"nuǎn":   "暖",
+	"nuó":    "挪",
+	"nuò":    "诺諾",
+	"ō":      "噢喔",
+	"ó":      "哦",
+	"ǒu":     "偶",
+	"pá":     "爬",
+	"pà":     "怕帕",
+	"pāi":    "拍",
+	"pái":    "排牌",
+	"pài":    "派",
+	"pān":    "攀",
+	"pán":    "盘盤",
+	"pàn":    "盼判叛畔",
+	"páng":   "旁",
+	"pàng":   "胖",
+	"pǎo":    "跑",
+	"pào":    "泡炮",
+	"péi":    "陪赔",
+	"pèi":    "配佩",
+	"pēn":    "喷噴",
+	"pén":    "盆",
+	"pēng":   "砰烹",
+	"péng":   "朋蓬棚",
+	"pěng":   "捧",
+	"pèng":   "碰",
+	"pī":     "批披劈",
+	"pí":     "皮疲啤脾",
+	"pì":     "屁譬",
+	"piān":   "篇偏",
+	"piàn":   "片骗騙",
+	"piāo":   "飘飄",
+	"piào":   "票漂",
+	"pīn":    "拼",
+	"pín":    "贫貧频",
+	"pǐn":    "品",
+	"pīng":   "乒",
+	"píng":   "平评評瓶凭憑苹蘋屏萍",
+	"pō":     "坡泼",
+	"pó":     "婆",
+	"pò":     "破迫魄",
+	"pū":     "扑铺",
+	"pú":     "葡",
+	"pǔ":     "普朴",
+	"pù":     "瀑",
+	"qī":     "七期妻欺漆戚栖",
+	"qí":     "其奇齐齊骑騎旗棋祈",
+	"qǐ":     "起岂启乞",
+	"qì":     "气氣汽器弃棄泣",
+	"qià":    "恰洽",
+	"qiān":   "千牵牽铅签簽迁",
+	"qián":   "前钱錢潜潛黔",
+	"qiǎn":   "浅淺遣",
+	"qiàn":   "欠歉",
+	"qiāng":  "枪槍腔",
+	"qiáng":  "强強墙牆",
+	"qiǎng":  "抢搶",
+	"qiāo":   "敲悄",
+	"qiáo":   "桥橋瞧乔",
+	"qiǎo":   "巧",
+	"qiào":   "翘",
+	"qiē":    "切",
+	"qiè":    "窃",
+	"qīn":    "亲親侵",
+	"qín":    "琴勤秦",
+	"qīng":   "青轻輕清倾傾",
+	"qíng":   "情晴",
+	"qǐng":   "请請",
+	"qìng":   "庆慶",
+	"qióng":  "穷窮",
+	"qiū":    "秋丘",
+	"qiú":    "求球囚",
+	"qū":     "区區趋驱屈",
+	"qǔ":     "取曲娶",
+	"qù":     "去趣",
+	"quān":   "圈",
+	"quán":   "全泉拳权權",
+	"quǎn":   "犬",
+	"quàn":   "劝勸",
+	"quē":    "缺",
+	"què":    "却卻确確雀",
+	"qún":    "群裙",
+	"rán":    "然燃",
+	"rǎn":    "染",
+	"ràng":   "让讓",
+	"rǎo":    "扰擾",
+	"rào":    "绕繞",
+	"rè":     "热熱",
+	"rén":    "人仁",
+	"rěn":    "忍",
+	"rèn":    "认認任",
+	"rēng":   "扔",
+	"réng":   "仍",
+	"rì":     "日",
+	"róng":   "容荣榮融溶",
+	"róu":    "柔揉",
+	"ròu":    "肉",
+	"rú":     "如",
+	"rù":     "入",
+	"ruǎn":   "软軟",
+	"ruì":    "锐",
+	"ruò":    "若弱",
+	"sā":     "撒",
+	"sǎ":     "洒灑",
+	"sài":    "赛賽",
+	"sān":    "三",
+	"sǎn":    "伞傘",
+	"sàn":    "散",
+	"sāng":   "桑",
+	"sàng":   "丧",
+	"sǎo":    "扫掃嫂",
+	"sè":     "色涩",
+	"sēn":    "森",
+	"shā":    "杀殺沙纱",
+	"shǎ":    "傻",
+	"shà":    "霎",
+	"shài":   "晒",
+	"shān":   "山衫删",
+	"shǎn":   "闪閃",
+	"shàn":   "善扇",
+	"shāng":  "伤傷商",
+	"shǎng":  "赏賞",
+	"shàng":  "上尚",
+	"shāo":   "烧燒稍",
+	"sháo":   "勺",
+	"shǎo":   "少",
+	"shào":   "绍",
+	"shé":    "蛇舌",
+	"shě":    "舍捨",
+	"shè":    "设設社射摄",
+	"shéi":   "谁誰",
+	"shēn":   "身深伸申",
+	"shén":   "神什",
+	"shěn":   "审",
+	"shèn":   "甚慎",
+	"shēng":  "生声聲升",
+	"shéng":  "绳",
+	"shěng":  "省",
+	"shèng":  "胜勝剩圣聖盛",
+	"shī":    "师師诗詩失湿濕施狮",
+	"shí":    "十时時石实實食识識拾",
+	"shǐ":    "使始史驶",
+	"shì":    "是事世市式试試视視室誓适適释",
+	"shōu":   "收",
+	"shǒu":   "手首守",
+	"shòu":   "受瘦兽授售",
+	"shū":    "书書输輸舒叔梳殊",
+	"shú":    "熟",
+	"shǔ":    "属屬鼠暑",
+	"shù":    "树樹数數术術束",
+	"shuā":   "刷",
+	"shuāi":  "摔衰",
+	"shuài":  "帅",
+	"shuāng": "双雙霜",
+	"shuǐ":   "水",
+	"shuì":   "睡税",
+	"shùn":   "顺順瞬",
+	"shuō":   "说說",
+	"sī":     "思丝絲私司撕斯",
+	"sǐ":     "死",
+	"sì":     "四似寺",
+	"sōng":   "松",
+	"sòng":   "送宋",
+	"sōu":    "搜",
+	"sū":     "苏蘇",
+	"sú":     "俗",
+	"sù":     "诉訴速素宿",
+	"suān":   "酸",
+	"suàn":   "算",
+	"suī":    "虽雖",
+	"suí":    "随隨",
+	"suì":    "岁歲碎",
+	"sūn":    "孙孫",
+	"sǔn":    "损",
+	"suō":    "缩",
+	"suǒ":    "所锁鎖索",
+	"tā":     "他她它塌",
+	"tǎ":     "塔",
+	"tà":     "踏",
+	"tāi":    "胎",
+	"tái":    "台抬",
+	"tài":    "太态態泰",
+	"tān":    "贪貪摊",
+	"tán":    "谈談弹彈潭",
+	"tǎn":    "坦",
+	"tàn":    "叹嘆探炭",
+	"tāng":   "汤湯",
+	"táng":   "糖堂唐",
+	"tǎng":   "躺淌",
+	"tàng":   "烫趟",
+	"tāo":    "掏涛濤",
+	"táo":    "逃桃陶",
+	"tǎo":    "讨討",
+	"tào":    "套",
+	"tè":     "特",
+	"téng":   "疼腾騰",
+	"tī":     "踢梯",
+	"tí":     "提题題啼",
+	"tǐ":     "体體",
+	"tì":     "替",
+	"tiān":   "天添",
+	"tián":   "甜田填",
+	"tiāo":   "挑",
+	"tiáo":   "条條调調",
+	"tiào":   "跳",
+	"tiē":    "贴貼",
+	"tiě":    "铁鐵",
+	"tīng":   "听聽厅",
+	"tíng":   "停庭",
+	"tǐng":   "挺",
+	"tōng":   "通",
+	"tóng":   "同童铜",
+	"tǒng":   "统統桶",
+	"tòng":   "痛",
+	"tōu":    "偷",
+	"tóu":    "头頭投",
+	"tòu":    "透",
+	"tū":     "突",
+	"tú":     "图圖途徒涂",
+	"tǔ":     "土吐",
+	"tù":     "兔",
+	"tuán":   "团團",
+	"tuī":    "推",
+	"tuǐ":    "腿",
+	"tuì":    "退",
+	"tūn":    "吞",
+	"tuō":    "拖脱脫托",
+	"tuǒ":    "妥",
+	"wa":     "哇",
+	"wā":     "挖",
+	"wá":     "娃",
+	"wǎ":     "瓦",
+	"wà":     "袜",
+	"wāi":    "歪",
+	"wài":    "外",
+	"wān":    "弯彎湾灣",
+	"wán":    "完玩顽",
+	"wǎn":    "晚碗挽",
+	"wàn":    "万萬",
+	"wāng":   "汪",
+	"wáng":   "王亡",
+	"wǎng":   "往网網枉惘",
+	"wàng":   "望忘旺妄",
+	"wēi":    "微危威",
+	"wéi":    "围圍唯违違维",
+	"wěi":    "伟偉尾委",
+	"wèi":    "为為未位味喂胃卫衛谓",
+	"wēn":    "温溫",
+	"wén":    "文闻聞纹",
+	"wěn":    "吻稳穩",
+	"wèn":    "问問",
+	"wō":     "窝",
+	"wǒ":     "我",
+	"wò":     "握卧",
+	"wū":     "屋污乌烏",
+	"wú":     "无無吾",
+	"wǔ":     "五午舞武伍",
+	"wù":     "物务務雾霧误誤悟勿",
+	"xī":     "西希息吸惜夕溪稀牺膝嘻熙",
+	"xí":     "习習席袭",
+	"xǐ":     "喜洗",
+	"xì":     "系细細戏戲",
+	"xiā":    "虾瞎",
+	"xià":    "下夏吓",
+	"xiān":   "先鲜鮮仙掀",
+	"xián":   "闲閒弦嫌咸",
+	"xiǎn":   "显顯险險",
+	"xiàn":   "现現线線限献陷羡",
+	"xiāng":  "香相乡鄉箱",
+	"xiǎng":  "想响響享",
+	"xiàng":  "向像象项巷",
+	"xiāo":   "消销宵萧",
+	"xiǎo":   "小晓曉",
+	"xiào":   "笑校效孝",
+	"xiē":    "些歇",
+	"xié":    "鞋协斜邪携",
+	"xiě":    "写寫",
+	"xiè":    "谢謝泄",
+	"xīn":    "心新辛欣薪",
+	"xìn":    "信",
+	"xīng":   "星腥",
+	"xíng":   "行形型",
+	"xǐng":   "醒",
+	"xìng":   "幸性姓兴興",
+	"xiōng":  "兄胸凶",
+	"xióng":  "雄熊",
+	"xiū":    "休修羞",
+	"xiù":    "秀袖绣",
+	"xū":     "需虚虛须須",
+	"xǔ":     "许許",
+	"xù":     "续續绪緒序叙",
+	"xuān":   "宣喧",
+	"xuán":   "旋悬懸玄",
+	"xuǎn":   "选選",
+	"xué":    "学學",
+	"xuě":    "雪",
+	"xuè":    "血",
+	"xún":    "寻尋巡询",
+	"xùn":    "训迅",
+	"yā":     "压壓鸭押",
+	"yá":     "牙崖芽",
+	"yǎ":     "哑雅",
+	"yà":     "亚亞",
+	"ya":     "呀",
+	"yān":    "烟煙淹",
+	"yán":    "言颜顏严嚴研延盐炎沿",
+	"yǎn":    "眼演掩",
+	"yàn":    "验驗燕艳焰宴厌厭",
+	"yāng":   "央殃",
+	"yáng":   "阳陽洋扬揚羊杨楊",
+	"yǎng":   "养養仰痒",
+	"yàng":   "样樣",
+	"yāo":    "腰邀妖",
+	"yáo":    "摇搖遥遙谣",
+	"yǎo":    "咬",
+	"yào":    "要药藥耀",
+	"yē":     "耶",
+	"yé":     "爷爺",
+	"yě":     "也野",
+	"yè":     "夜叶葉业業页頁液",
+	"yī":     "一衣医醫依",
+	"yí":     "移疑遗遺姨仪",
+	"yǐ":     "以已椅乙",
+	"yì":     "意义義易亿忆憶艺藝议議异異益翼译譯",
+	"yīn":    "因音阴陰",
+	"yín":    "银銀吟",
+	"yǐn":    "引饮飲隐隱",
+	"yìn":    "印",
+	"yīng":   "应應英鹰樱櫻婴",
+	"yíng":   "迎营營赢贏萤",
+	"yǐng":   "影",
+	"yìng":   "硬映",
+	"yōng":   "拥擁庸",
+	"yǒng":   "永勇涌泳",
+	"yòng":   "用",
+	"yōu":    "优優忧憂幽悠",
+	"yóu":    "由游遊油尤邮",
+	"yǒu":    "有友",
+	"yòu":    "又右幼诱",
+	"yú":     "于於鱼魚余餘愉娱虞渔",
+	"yǔ":     "雨语語与與宇羽",
+	"yù":     "遇玉欲育预預域狱御寓愈郁",
+	"yuān":   "冤渊",
+	"yuán":   "元原园園员員圆圓源缘緣",
+	"yuǎn":   "远遠",
+	"yuàn":   "愿願院怨",
+	"yuē":    "约約",
+	"yuè":    "月越悦阅跃",
+	"yūn":    "晕",
+	"yún":    "云雲",
+	"yǔn":    "允",
+	"yùn":    "运運韵孕",
+	"zá":     "杂雜砸",
+	"zāi":    "灾災",
+	"zài":    "在再载",
+	"zán":    "咱",
+	"zàn":    "赞讚暂",
+	"zāng":   "脏髒",
+	"zāo":    "遭糟",
+	"zǎo":    "早",
+	"zào":    "造燥躁",
+	"zé":     "则則责責泽澤",
+	"zěn":    "怎",
+	"zēng":   "增",
+	"zèng":   "赠",
+	"zhā":    "扎",
+	"zhǎ":    "眨",
+	"zhà":    "炸",
+	"zhāi":   "摘",
+	"zhǎi":   "窄",
+	"zhān":   "沾粘",
+	"zhǎn":   "展",
+	"zhàn":   "站战戰占",
+	"zhāng":  "张張章",
+	"zhǎng":  "掌涨",
+	"zhàng":  "丈帐仗",
+	"zhǎo":   "找",
+	"zhào":   "照赵召",
+	"zhē":    "遮",
+	"zhé":    "折哲",
+	"zhě":    "者",
+	"zhè":    "这這",
+	"zhe":    "着著",
+	"zhēn":   "真针針珍",
+	"zhěn":   "枕",
+	"zhèn":   "阵陣振震镇",
+	"zhēng":  "争爭睁征蒸",
+	"zhěng":  "整",
+	"zhèng":  "正证證政",
+	"zhī":    "之知支枝织織汁隻",
+	"zhí":    "直值职職植执",
+	"zhǐ":    "只纸紙指止",
+	"zhì":    "至制治志置智质質致",
+	"zhōng":  "中钟鐘终終忠",
+	"zhǒng":  "种種肿",
+	"zhòng":  "重众眾",
+	"zhōu":   "周州洲舟",
+	"zhòu":   "皱皺昼",
+	"zhū":    "猪珠朱株",
+	"zhú":    "竹逐烛",
+	"zhǔ":    "主煮嘱",
+	"zhù":    "住注助祝驻柱",
+	"zhuā":   "抓",
+	"zhuān":  "专專砖",
+	"zhuǎn":  "转轉",
+	"zhuàn":  "赚",
+	"zhuāng": "装裝庄妆",
+	"zhuàng": "撞状狀壮",
+	"zhuī":   "追",
+	"zhǔn":   "准準",
+	"zhuō":   "捉桌",
+	"zī":     "资姿滋",
+	"zǐ":     "紫仔",
+	"zi":     "子",
+	"zì":     "自字",
+	"zōng":   "宗踪",
+	"zǒng":   "总總",
+	"zòng":   "纵",
+	"zǒu":    "走",
+	"zòu":    "奏",
+	"zū":     "租",
+	"zú":     "足族",
+	"zǔ":     "组組祖阻",
+	"zuān":   "钻",
+	"zuǐ":    "嘴",
+	"zuì":    "最醉罪",
+	"zūn":    "尊",
+	"zuó":    "昨",
+	"zuǒ":    "左",
+	"zuò":    "做作坐座",
+}
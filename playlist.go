@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var ErrEmptyPlaylist = errors.New("playlist has no entries")
+
+// playlistPathPattern matches YouTube playlist pages and channel pages, including channel tabs such as /@name/videos.
+var playlistPathPattern = regexp.MustCompile(`^/(playlist|@[^/]+|channel/[^/]+|c/[^/]+|user/[^/]+)(/|$)`)
+
+// playlistEntry is one item of `yt-dlp --flat-playlist -J` output. Nested playlists carry entries of their own.
+type playlistEntry struct {
+	ID           string          `json:"id"`
+	Title        string          `json:"title"`
+	URL          string          `json:"url"`
+	Availability string          `json:"availability"`
+	Entries      []playlistEntry `json:"entries"`
+}
+
+// playlistInfo is the subset of `yt-dlp --flat-playlist -J` output used for playlist mode.
+type playlistInfo struct {
+	Title   string          `json:"title"`
+	Entries []playlistEntry `json:"entries"`
+}
+
+// isPlaylistURL reports whether input is a YouTube playlist or channel page, which always means
+// playlist mode since there is no single video to fall back to.
+func isPlaylistURL(input string) bool {
+	if !isYouTubeURL(input) {
+		return false
+	}
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	parsedURL, err := url.Parse(input)
+	if err != nil {
+		return false
+	}
+	return playlistPathPattern.MatchString(parsedURL.Path)
+}
+
+// listPlaylist enumerates a playlist or channel with yt-dlp without downloading anything.
+// Nested playlists are flattened, and entries that are themselves channel tabs are enumerated once more.
+func listPlaylist(playlistURL string, verbose bool) (*playlistInfo, error) {
+	return listPlaylistDepth(playlistURL, verbose, 0)
+}
+
+// listPlaylistDepth is listPlaylist with the nesting depth reached so far, which stops channel
+// tabs from being expanded more than one level.
+func listPlaylistDepth(playlistURL string, verbose bool, depth int) (*playlistInfo, error) {
+	cmd := exec.Command("yt-dlp", "--flat-playlist", "--yes-playlist", "-J", playlistURL)
+	if verbose {
+		cmd.Stderr = os.Stderr
+	}
+
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, newError("list playlist entries", err)
+	}
+
+	var playlist playlistInfo
+	if err := json.Unmarshal(data, &playlist); err != nil {
+		return nil, newError("parse playlist entries", err)
+	}
+
+	var entries []playlistEntry
+	var flatten func([]playlistEntry) error
+	flatten = func(items []playlistEntry) error {
+		for _, entry := range items {
+			switch {
+			case len(entry.Entries) > 0:
+				if err := flatten(entry.Entries); err != nil {
+					return err
+				}
+			case depth == 0 && isPlaylistURL(entry.URL):
+				nested, err := listPlaylistDepth(entry.URL, verbose, depth+1)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, nested.Entries...)
+			default:
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	}
+	if err := flatten(playlist.Entries); err != nil {
+		return nil, err
+	}
+
+	playlist.Entries = entries
+	return &playlist, nil
+}
+
+// isUnavailableEntry reports whether a playlist entry cannot be downloaded, such as private or deleted videos.
+func isUnavailableEntry(entry playlistEntry) bool {
+	switch entry.Availability {
+	case "private", "premium_only", "subscriber_only", "needs_auth":
+		return true
+	}
+	return entry.URL == "" || entry.Title == "[Private video]" || entry.Title == "[Deleted video]"
+}
+
+// runPlaylist transcribes every entry of a playlist or channel. Items are written to a folder named
+// after the playlist as "NN - Title", numbered in playlist order. Unavailable entries and entries whose
+// JSON transcription already exists are skipped, so an interrupted run can be resumed, and a failed
+// item does not stop the rest. A summary table is printed at the end.
+func runPlaylist(playlistURL string, config *Config) error {
+	if config.Output != "" {
+		return newError("transcribe playlist", ErrOutputWithMultiple)
+	}
+
+	download("Listing playlist entries...")
+	playlist, err := listPlaylist(playlistURL, config.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(playlist.Entries) == 0 {
+		return newError("transcribe playlist", fmt.Errorf("%w: %s", ErrEmptyPlaylist, playlistURL))
+	}
+
+	folder := safeFileName(playlist.Title)
+	width := len(fmt.Sprint(len(playlist.Entries)))
+	header(fmt.Sprintf("Playlist: %s (%d items)", playlist.Title, len(playlist.Entries)))
+
+	results := make([]batchResult, 0, len(playlist.Entries))
+	for i, entry := range playlist.Entries {
+		name := fmt.Sprintf("%0*d - %s", max(width, 2), i+1, safeFileName(entry.Title))
+		result := batchResult{Name: firstNonEmpty(entry.Title, entry.ID), Status: batchDone}
+		jsonPath := filepath.Join(config.OutputDir, folder, name+".json")
+
+		switch {
+		case isUnavailableEntry(entry):
+			result.Status, result.Detail = batchSkipped, "unavailable"
+		case fileExists(jsonPath):
+			result.Status, result.Detail = batchSkipped, "already transcribed"
+		default:
+			header(fmt.Sprintf("[%d/%d] %s", i+1, len(playlist.Entries), result.Name))
+			itemConfig := *config
+			itemConfig.Output = filepath.Join(folder, name)
+			if err := transcribeInput(entry.URL, &itemConfig); err != nil {
+				errorMsg(err.Error())
+				result.Status, result.Detail = batchFailed, err.Error()
+			} else {
+				result.Detail = jsonPath
+			}
+		}
+		results = append(results, result)
+	}
+
+	printBatchSummary(results)
+	return batchError("transcribe playlist", results)
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
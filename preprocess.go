@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	normalizedSampleRate = 16000
+	// loudnormFilter targets EBU R128 streaming loudness so quiet and mastered-loud tracks reach Whisper alike.
+	loudnormFilter = "loudnorm=I=-16:TP=-1.5:LRA=11"
+)
+
+var ErrInvalidFilterFrequency = errors.New("invalid filter frequency")
+
+// validateFilterFrequencies checks the -highpass and -lowpass cutoffs. Zero disables a filter, and when
+// both are set the low-pass cutoff must lie above the high-pass cutoff.
+func validateFilterFrequencies(highPass, lowPass int) error {
+	if highPass < 0 || lowPass < 0 {
+		return fmt.Errorf("%w: cutoffs must not be negative", ErrInvalidFilterFrequency)
+	}
+	if highPass > 0 && lowPass > 0 && lowPass <= highPass {
+		return fmt.Errorf("%w: -lowpass %d Hz must be above -highpass %d Hz", ErrInvalidFilterFrequency, lowPass, highPass)
+	}
+	return nil
+}
+
+// audioFilters builds the ffmpeg filter chain: optional band limiting followed by loudness normalization.
+func audioFilters(config *Config) string {
+	var filters []string
+	if config.HighPass > 0 {
+		filters = append(filters, fmt.Sprintf("highpass=f=%d", config.HighPass))
+	}
+	if config.LowPass > 0 {
+		filters = append(filters, fmt.Sprintf("lowpass=f=%d", config.LowPass))
+	}
+	return strings.Join(append(filters, loudnormFilter), ",")
+}
+
+// normalizeAudio uses ffmpeg to turn any input ffmpeg can read, including video files, into a
+// loudness-normalized 16 kHz mono PCM WAV in a temporary directory. This is the format Whisper resamples
+// to internally, so every backend sees the same audio whether the source was mp3, m4a, flac or ogg.
+func normalizeAudio(audioPath string, config *Config) (string, func(), error) {
+	processing("Normalizing audio with ffmpeg...")
+	step("Filters: " + audioFilters(config))
+
+	tmpDir, err := os.MkdirTemp("", "echowave-normalize-*")
+	if err != nil {
+		return "", nil, newError("create temp directory", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			warning(fmt.Sprintf("Failed to cleanup temp files: %v", err))
+		}
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
+	wavPath := filepath.Join(tmpDir, baseName+".wav")
+
+	cmd := exec.Command("ffmpeg", "-y", "-loglevel", "error", "-i", audioPath, "-vn",
+		"-af", audioFilters(config), "-ar", fmt.Sprint(normalizedSampleRate), "-ac", "1", "-c:a", "pcm_s16le", wavPath)
+	if config.Verbose {
+		cmd.Stdout = uiWriter
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return "", nil, newError("normalize audio", err)
+	}
+
+	success("Audio normalized")
+	return wavPath, cleanup, nil
+}
+
+// preprocessAudio prepares the file handed to the transcription backend: vocal isolation first, on the
+// full-quality mix, then ffmpeg normalization. Output names and track metadata still come from the
+// original audioPath; only the audio Whisper hears is changed. The returned cleanup removes every
+// intermediate file.
+func preprocessAudio(audioPath string, config *Config) (string, func(), error) {
+	path := audioPath
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+
+	if config.IsolateVocals {
+		stemPath, done, err := isolateVocals(path, config)
+		if err != nil {
+			return "", nil, err
+		}
+		path = stemPath
+		cleanups = append(cleanups, done)
+	}
+
+	if config.Normalize {
+		wavPath, done, err := normalizeAudio(path, config)
+		if err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		path = wavPath
+		cleanups = append(cleanups, done)
+	}
+
+	return path, cleanup, nil
+}
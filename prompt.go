@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// readVocabulary reads a vocabulary file of artist names, slang and other terms Whisper should spell
+// correctly, one term per line so names containing commas stay whole. Blank lines and lines starting
+// with # are ignored.
+func readVocabulary(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		terms = append(terms, line)
+	}
+	return terms, nil
+}
+
+// buildPrompt combines -prompt, -prompt-file and -vocabulary into the initial prompt handed to the
+// backend. Vocabulary comes first as a glossary sentence, followed by the prompt text with whitespace
+// collapsed to single spaces. Whisper only keeps the last 224 tokens of a prompt, so the lyrics text
+// is the part that survives when a long prompt is cut.
+func buildPrompt(prompt, promptFile, vocabularyFile string) (string, error) {
+	var parts []string
+
+	if vocabularyFile != "" {
+		terms, err := readVocabulary(vocabularyFile)
+		if err != nil {
+			return "", newError("read vocabulary file", err)
+		}
+		if len(terms) > 0 {
+			parts = append(parts, "Glossary: "+strings.Join(terms, ", ")+".")
+		}
+	}
+
+	if promptFile != "" {
+		data, err := os.ReadFile(promptFile)
+		if err != nil {
+			return "", newError("read prompt file", err)
+		}
+		parts = append(parts, string(data))
+	}
+	parts = append(parts, prompt)
+
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " "), nil
+}
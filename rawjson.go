@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// rawFields holds the members of a backend JSON object that EchoWave does not model, such as Whisper's
+// tokens, temperature, compression_ratio, seek and id. They are written back unchanged, so the .json
+// output keeps the backend's document and only EchoWave's own fields are added or overwritten.
+type rawFields map[string]json.RawMessage
+
+// seekField is Whisper's offset of the decoding window a segment came from. It describes the audio the
+// backend saw, so it goes stale once a segment is moved onto another timeline.
+const seekField = "seek"
+
+// idField is Whisper's segment number, renumbered on write so it matches the segment's position.
+const idField = "id"
+
+var (
+	outputFieldNames  = jsonFieldNames(reflect.TypeOf(WhisperOutput{}))
+	segmentFieldNames = jsonFieldNames(reflect.TypeOf(Segment{}))
+)
+
+// jsonFieldNames lists the JSON member names encoding/json uses for the exported fields of a struct type.
+func jsonFieldNames(structType reflect.Type) []string {
+	var names []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		names = append(names, firstNonEmpty(name, field.Name))
+	}
+	return names
+}
+
+// unknownFields returns the members of the JSON object in data that are not in known, or nil when there are none.
+func unknownFields(data []byte, known []string) (rawFields, error) {
+	var fields rawFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range known {
+		delete(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// without returns the raw fields minus names. The receiver is never modified; it is returned as is
+// when none of the names are present, and cloned otherwise.
+func (raw rawFields) without(names ...string) rawFields {
+	var clone rawFields
+	for _, name := range names {
+		if _, ok := raw[name]; !ok {
+			continue
+		}
+		if clone == nil {
+			clone = maps.Clone(raw)
+		}
+		delete(clone, name)
+	}
+	if clone == nil {
+		return raw
+	}
+	return clone
+}
+
+// numberedSegments returns the segments with every backend id replaced by the segment's position, so
+// ids stay unique and in order after segments were dropped, stitched from chunks or re-timed.
+func numberedSegments(segments []Segment) []Segment {
+	numbered := make([]Segment, len(segments))
+	for i, segment := range segments {
+		if id, ok := segment.Raw[idField]; ok && string(id) != strconv.Itoa(i) {
+			segment.Raw = maps.Clone(segment.Raw)
+			segment.Raw[idField] = json.RawMessage(strconv.Itoa(i))
+		}
+		numbered[i] = segment
+	}
+	return numbered
+}
+
+// marshalWithRawFields encodes value and merges the preserved raw members into the resulting object.
+// Fields EchoWave models always win over raw members of the same name.
+func marshalWithRawFields(value any, raw rawFields) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || len(raw) == 0 {
+		return data, err
+	}
+
+	var fields rawFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, member := range raw {
+		if _, ok := fields[name]; !ok {
+			fields[name] = member
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a backend document and keeps the members EchoWave does not model.
+func (output *WhisperOutput) UnmarshalJSON(data []byte) error {
+	type plain WhisperOutput
+	if err := json.Unmarshal(data, (*plain)(output)); err != nil {
+		return err
+	}
+	raw, err := unknownFields(data, outputFieldNames)
+	output.Raw = raw
+	return err
+}
+
+// MarshalJSON encodes the transcript with the backend's unmodelled members restored and segment ids renumbered.
+func (output WhisperOutput) MarshalJSON() ([]byte, error) {
+	type plain WhisperOutput
+	output.Segments = numberedSegments(output.Segments)
+	return marshalWithRawFields(plain(output), output.Raw)
+}
+
+// UnmarshalJSON decodes a backend segment and keeps the members EchoWave does not model.
+func (segment *Segment) UnmarshalJSON(data []byte) error {
+	type plain Segment
+	if err := json.Unmarshal(data, (*plain)(segment)); err != nil {
+		return err
+	}
+	raw, err := unknownFields(data, segmentFieldNames)
+	segment.Raw = raw
+	return err
+}
+
+// MarshalJSON encodes the segment with the backend's unmodelled members restored.
+func (segment Segment) MarshalJSON() ([]byte, error) {
+	type plain Segment
+	return marshalWithRawFields(plain(segment), segment.Raw)
+}
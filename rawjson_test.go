@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const whisperDocument = `{
+	"text": " Hello world. Second line.",
+	"language": "en",
+	"segments": [
+		{"id": 0, "seek": 0, "start": 0.0, "end": 2.0, "text": " Hello world.", "tokens": [50364, 2425], "temperature": 0.0,
+		 "avg_logprob": -0.2, "compression_ratio": 0.9, "no_speech_prob": 0.01,
+		 "words": [{"word": " Hello", "start": 0.0, "end": 1.0, "probability": 0.9}]},
+		{"id": 1, "seek": 0, "start": 2.0, "end": 4.0, "text": " Second line.", "tokens": [1234], "temperature": 0.2,
+		 "avg_logprob": -0.3, "compression_ratio": 1.1, "no_speech_prob": 0.02, "words": []}
+	]
+}`
+
+func TestWhisperOutputKeepsBackendFields(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "song.json")
+	if err := os.WriteFile(jsonPath, []byte(whisperDocument), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := loadWhisperOutput(jsonPath)
+	if err != nil {
+		t.Fatalf("loadWhisperOutput: %v", err)
+	}
+
+	// Edit the transcript the way the pipeline does before writing it.
+	output.Segments = output.Segments[1:]
+	shiftTranscript(output, 10)
+	output.Metadata = &TranscriptMetadata{Title: "Song"}
+	if err := writeWhisperOutput(jsonPath, output); err != nil {
+		t.Fatalf("writeWhisperOutput: %v", err)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		Language string                       `json:"language"`
+		Segments []map[string]json.RawMessage `json:"segments"`
+		EchoWave *TranscriptMetadata          `json:"echowave"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("written JSON does not parse: %v", err)
+	}
+
+	if document.Language != "en" || document.EchoWave == nil || document.EchoWave.Title != "Song" {
+		t.Errorf("top level = %+v", document)
+	}
+	if len(document.Segments) != 1 {
+		t.Fatalf("got %d segments, want 1", len(document.Segments))
+	}
+	segment := document.Segments[0]
+	for name, want := range map[string]string{
+		"id":                "0",
+		"tokens":            "[1234]",
+		"temperature":       "0.2",
+		"compression_ratio": "1.1",
+		"start":             "12",
+	} {
+		if got := string(segment[name]); got != want {
+			t.Errorf("segment %s = %s, want %s", name, got, want)
+		}
+	}
+	if seek, ok := segment["seek"]; ok {
+		t.Errorf("shifted segment kept the backend's seek %s", seek)
+	}
+
+	again, err := loadWhisperOutput(jsonPath)
+	if err != nil {
+		t.Fatalf("reloading written JSON: %v", err)
+	}
+	if again.Segments[0].Text != " Second line." || again.Segments[0].Start != 12 {
+		t.Errorf("reloaded segment = %+v", again.Segments[0])
+	}
+}
+
+func TestStitchedSegmentsGetFreshIDs(t *testing.T) {
+	chunk := func() *WhisperOutput {
+		var output WhisperOutput
+		if err := json.Unmarshal([]byte(whisperDocument), &output); err != nil {
+			t.Fatal(err)
+		}
+		return &output
+	}
+	chunks := []audioChunk{{Start: 0, End: 4, AudioStart: 0}, {Start: 4, End: 8, AudioStart: 4}}
+	stitched := stitchChunks(chunks, []*WhisperOutput{chunk(), chunk()})
+
+	data, err := json.Marshal(stitched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		Segments []map[string]json.RawMessage `json:"segments"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(document.Segments) != 4 {
+		t.Fatalf("got %d segments, want 4", len(document.Segments))
+	}
+	for i, segment := range document.Segments {
+		if got, want := string(segment["id"]), fmt.Sprint(i); got != want {
+			t.Errorf("segment %d id = %s, want %s", i, got, want)
+		}
+		if seek, ok := segment["seek"]; ok {
+			t.Errorf("segment %d kept its chunk's seek %s", i, seek)
+		}
+	}
+	if string(stitched.Segments[0].Raw["id"]) != "0" || string(stitched.Segments[3].Raw["id"]) != "1" {
+		t.Error("writing renumbered the ids in place")
+	}
+}
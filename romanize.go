@@ -0,0 +1,428 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	hangulBase         = 0xAC00
+	hangulLast         = 0xD7A3
+	hangulVowelCount   = 21
+	hangulFinalCount   = 28
+	hangulSilentIerung = 11
+	hangulInitialRieul = 5
+	hangulInitialNieun = 2
+	hangulFinalNieun   = 4
+	katakanaFirst      = 0x30A1
+	katakanaLast       = 0x30F6
+	katakanaOffset     = 0x60
+)
+
+var ErrRomanizationUnsupported = errors.New("romanization is not supported for language")
+
+// romanizers maps a transcription language to the function that writes its lyrics in Latin script.
+var romanizers = map[string]func(string) string{
+	"ja": romanizeJapanese,
+	"ko": romanizeKorean,
+	"zh": romanizeChinese,
+	"ru": romanizeCyrillic,
+}
+
+// romanizer returns the romanization function for a language, or an error for unsupported languages.
+func romanizer(language string) (func(string) string, error) {
+	romanize, ok := romanizers[language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (supported: ja, ko, zh, ru)", ErrRomanizationUnsupported, firstNonEmpty(language, "unknown"))
+	}
+	return romanize, nil
+}
+
+// cjkPunctuation maps full-width punctuation to ASCII so romanized lines read naturally.
+var cjkPunctuation = map[rune]string{
+	'，': ", ", '、': ", ", '。': ". ", '！': "! ", '？': "? ", '：': ": ", '；': "; ",
+	'「': "\"", '」': "\"", '『': "\"", '』': "\"", '（': " (", '）': ") ",
+	'　': " ", '…': "...", '～': "~", '〜': "~", '・': " ",
+}
+
+// tidyRomanization collapses the spacing left behind by syllable separators and punctuation.
+func tidyRomanization(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	for _, mark := range []string{",", ".", "!", "?", ":", ";", ")"} {
+		text = strings.ReplaceAll(text, " "+mark, mark)
+	}
+	return strings.ReplaceAll(text, "( ", "(")
+}
+
+// pinyinTable indexes pinyinReadings by character.
+var pinyinTable = buildPinyinTable()
+
+// buildPinyinTable inverts pinyinReadings into a character-to-reading lookup.
+func buildPinyinTable() map[rune]string {
+	table := make(map[rune]string)
+	for reading, characters := range pinyinReadings {
+		for _, character := range characters {
+			table[character] = reading
+		}
+	}
+	return table
+}
+
+// romanizeChinese writes Chinese text as tone-marked Hanyu Pinyin with one space between syllables.
+// Characters missing from the reading table are kept as they are.
+func romanizeChinese(text string) string {
+	var b strings.Builder
+	afterSyllable := false
+
+	for _, r := range text {
+		if reading, ok := pinyinTable[r]; ok {
+			b.WriteString(" " + reading)
+			afterSyllable = true
+			continue
+		}
+		if punctuation, ok := cjkPunctuation[r]; ok {
+			b.WriteString(punctuation)
+			afterSyllable = false
+			continue
+		}
+		if afterSyllable && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+		afterSyllable = false
+	}
+
+	return tidyRomanization(b.String())
+}
+
+// Revised Romanization of Korean, indexed by the jamo positions of a precomposed Hangul syllable.
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulVowels   = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+// hangulLinkedFinals gives, for each final consonant, what stays in the syllable and what is carried
+// over as the next initial when the following syllable starts with the silent ㅇ (e.g. 한국어 hangugeo).
+var hangulLinkedFinals = [hangulFinalCount][2]string{
+	{"", ""}, {"", "g"}, {"", "kk"}, {"k", "s"}, {"", "n"}, {"n", "j"}, {"", "n"}, {"", "d"},
+	{"", "r"}, {"l", "g"}, {"l", "m"}, {"l", "b"}, {"l", "s"}, {"l", "t"}, {"l", "p"}, {"", "r"},
+	{"", "m"}, {"", "b"}, {"p", "s"}, {"", "s"}, {"", "ss"}, {"ng", ""}, {"", "j"}, {"", "ch"},
+	{"", "k"}, {"", "t"}, {"", "p"}, {"", ""},
+}
+
+// isHangulSyllable reports whether r is a precomposed Hangul syllable.
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+// romanizeKorean writes Hangul in Revised Romanization. Syllables are decomposed into their jamo
+// arithmetically; a final consonant moves to a following silent ㅇ and ㄹㄹ, ㄹㄴ and ㄴㄹ become "ll",
+// while other sound changes across syllables are not applied.
+func romanizeKorean(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	carried := ""
+	hasCarry := false
+
+	for i, r := range runes {
+		if !isHangulSyllable(r) {
+			if punctuation, ok := cjkPunctuation[r]; ok {
+				b.WriteString(punctuation)
+			} else {
+				b.WriteRune(r)
+			}
+			hasCarry = false
+			continue
+		}
+
+		index := int(r - hangulBase)
+		initial := index / (hangulVowelCount * hangulFinalCount)
+		vowel := index % (hangulVowelCount * hangulFinalCount) / hangulFinalCount
+		final := index % hangulFinalCount
+
+		initialText := hangulInitials[initial]
+		if hasCarry {
+			initialText = carried
+		}
+		finalText := hangulFinals[final]
+		hasCarry = false
+
+		if i+1 < len(runes) && isHangulSyllable(runes[i+1]) {
+			nextInitial := int(runes[i+1]-hangulBase) / (hangulVowelCount * hangulFinalCount)
+			switch {
+			case nextInitial == hangulSilentIerung && final != 0:
+				finalText, carried = hangulLinkedFinals[final][0], hangulLinkedFinals[final][1]
+				hasCarry = true
+			case (nextInitial == hangulInitialRieul || nextInitial == hangulInitialNieun) && finalText == "l":
+				carried = "l"
+				hasCarry = true
+			case nextInitial == hangulInitialRieul && final == hangulFinalNieun:
+				finalText, carried = "l", "l"
+				hasCarry = true
+			}
+		}
+
+		b.WriteString(initialText + hangulVowels[vowel] + finalText)
+	}
+
+	return tidyRomanization(b.String())
+}
+
+// kanaRomaji maps hiragana to modified Hepburn romaji. Katakana is folded onto hiragana first.
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゎ': "wa", 'ゕ': "ka", 'ゖ': "ke",
+}
+
+// kanaYoon holds the small kana that combine with the preceding kana (きゃ kya, ティ ti, ファ fa).
+var kanaYoon = map[rune]string{
+	'ゃ': "a", 'ゅ': "u", 'ょ': "o", 'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+// foldKatakana maps katakana onto the corresponding hiragana so one table covers both scripts.
+func foldKatakana(r rune) rune {
+	if r >= katakanaFirst && r <= katakanaLast {
+		return r - katakanaOffset
+	}
+	return r
+}
+
+// isRomajiVowel reports whether b is one of the five romaji vowels.
+func isRomajiVowel(b byte) bool {
+	return strings.IndexByte("aiueo", b) >= 0
+}
+
+// canCombineKana reports whether a small kana merges into the kana before it. Small ya/yu/yo and small
+// vowels follow consonant kana; a small vowel after う forms the w-sounds of loanwords (ウィ wi).
+func canCombineKana(r rune, romaji string, small rune) bool {
+	if _, ok := kanaYoon[small]; !ok || r == 'ん' {
+		return false
+	}
+	if len(romaji) > 1 {
+		return true
+	}
+	return r == 'う' && small != 'ゃ' && small != 'ゅ' && small != 'ょ'
+}
+
+// combineKana joins a kana with a following small kana: i-row kana take ya/yu/yo (shi, chi and ji
+// drop the y), and other kana swap their vowel for the small vowel, as in katakana loanwords.
+func combineKana(romaji string, small rune) string {
+	vowel := kanaYoon[small]
+	stem := romaji[:len(romaji)-1]
+	switch {
+	case small == 'ゃ' || small == 'ゅ' || small == 'ょ':
+		if stem == "sh" || stem == "ch" || stem == "j" {
+			return stem + vowel
+		}
+		return stem + "y" + vowel
+	case stem == "":
+		return "w" + vowel
+	default:
+		return stem + vowel
+	}
+}
+
+// romanizeJapanese writes kana in modified Hepburn: っ doubles the next consonant, ー repeats the
+// previous vowel and ん takes an apostrophe before a vowel or y. Kanji readings depend on context and
+// need a dictionary, so kanji are kept as they are, set off from the romaji by spaces.
+func romanizeJapanese(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		runes[i] = foldKatakana(r)
+	}
+
+	var b strings.Builder
+	geminate := false
+	lastVowel := byte(0)
+	afterKanji := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == 'っ' {
+			geminate = true
+			continue
+		}
+		if r == 'ー' {
+			if lastVowel != 0 {
+				b.WriteByte(lastVowel)
+			}
+			continue
+		}
+
+		romaji, ok := kanaRomaji[r]
+		if !ok {
+			geminate = false
+			lastVowel = 0
+			if isHan(r) && !afterKanji {
+				b.WriteByte(' ')
+			}
+			afterKanji = isHan(r)
+			if punctuation, ok := cjkPunctuation[r]; ok {
+				b.WriteString(punctuation)
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		if afterKanji {
+			b.WriteByte(' ')
+			afterKanji = false
+		}
+
+		if next := runeAt(runes, i+1); canCombineKana(r, romaji, next) {
+			romaji = combineKana(romaji, next)
+			i++
+		}
+
+		if r == 'ん' {
+			if next, ok := kanaRomaji[runeAt(runes, i+1)]; ok && (isRomajiVowel(next[0]) || next[0] == 'y') {
+				romaji += "'"
+			}
+		}
+
+		if geminate {
+			if strings.HasPrefix(romaji, "ch") {
+				b.WriteByte('t')
+			} else if !isRomajiVowel(romaji[0]) {
+				b.WriteByte(romaji[0])
+			}
+			geminate = false
+		}
+
+		b.WriteString(romaji)
+		lastVowel = romaji[len(romaji)-1]
+		if !isRomajiVowel(lastVowel) {
+			lastVowel = 0
+		}
+	}
+
+	return tidyRomanization(b.String())
+}
+
+// runeAt returns the rune at index i, or zero past the end of the slice.
+func runeAt(runes []rune, i int) rune {
+	if i < len(runes) {
+		return runes[i]
+	}
+	return 0
+}
+
+// cyrillicLatin is a practical (BGN/PCGN-style) Latin transliteration of lowercase Russian Cyrillic,
+// with the extra Ukrainian and Belarusian letters.
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// romanizeCyrillic transliterates Cyrillic letters to Latin, keeping the capitalisation of each letter.
+func romanizeCyrillic(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		latin, ok := cyrillicLatin[unicode.ToLower(r)]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.IsUpper(r) && latin != "" {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+		b.WriteString(latin)
+	}
+	return b.String()
+}
+
+// isHan reports whether r is a Han character (a kanji or hanzi).
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// romanizeOutput returns the romanized text of every segment, using the transcription language.
+// Japanese kanji and Chinese characters missing from the reading table stay as written around the
+// romanized text; one warning counts the lines that still contain them.
+func romanizeOutput(output *WhisperOutput, config *Config) ([]string, error) {
+	romanize, err := romanizer(transcriptLanguage(output, config))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, len(output.Segments))
+	partial := 0
+	for i, segment := range output.Segments {
+		lines[i] = romanize(strings.TrimSpace(segment.Text))
+		if strings.ContainsFunc(lines[i], isHan) {
+			partial++
+		}
+	}
+	if partial > 0 {
+		warning(fmt.Sprintf("%d of %d romanized lines keep kanji or other Han characters as written, since their readings are not known", partial, len(lines)))
+	}
+	return lines, nil
+}
+
+// romanizationConfig checks that a transcription's language can be romanized when -romanize is set.
+// Otherwise it warns and returns a copy of the config with romanization off, so the remaining formats are
+// still written and other files in a convert batch are unaffected.
+func romanizationConfig(output *WhisperOutput, config *Config) *Config {
+	if !config.Romanize {
+		return config
+	}
+	_, err := romanizer(transcriptLanguage(output, config))
+	if err == nil {
+		return config
+	}
+	warning("Skipping romanization: " + err.Error())
+
+	skipped := *config
+	skipped.Romanize = false
+	skipped.Formats = slices.DeleteFunc(slices.Clone(config.Formats), func(name string) bool {
+		return name == "romanized"
+	})
+	return &skipped
+}
+
+// writeRomanizedLRC renders each original line followed by its romanization under the same timestamp,
+// so players such as better-lyrics show the romanized line beneath the original.
+func writeRomanizedLRC(w io.Writer, output *WhisperOutput, config *Config) error {
+	lines, err := romanizeOutput(output, config)
+	if err != nil {
+		return newError("write romanized LRC", err)
+	}
+	if _, err := io.WriteString(w, lrcHeader(output)); err != nil {
+		return newError("write romanized LRC header", err)
+	}
+
+	for i, segment := range output.Segments {
+		timestamp := secondsToLRCTimestamp(segment.Start)
+		text := timestamp + " " + strings.TrimSpace(segment.Text) + "\n"
+		if lines[i] != "" && lines[i] != strings.TrimSpace(segment.Text) {
+			text += timestamp + " " + lines[i] + "\n"
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return newError("write romanized LRC content", err)
+		}
+	}
+	return nil
+}
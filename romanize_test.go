@@ -0,0 +1,112 @@
+package main
+
+import "testing"
+
+func TestRomanizeJapaneseHepburn(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"さくら", "sakura"},
+		{"しちつふじ", "shichitsufuji"},
+		{"きょう", "kyou"},
+		{"しゃしん", "shashin"},
+		{"がっこう", "gakkou"},
+		{"まっちゃ", "matcha"},
+		{"しんや", "shin'ya"},
+		{"せんえん", "sen'en"},
+		{"コーヒー", "koohii"},
+		{"ファン", "fan"},
+		{"ティー", "tii"},
+		{"さくら、さくら。", "sakura, sakura."},
+	}
+
+	for _, test := range tests {
+		if got := romanizeJapanese(test.text); got != test.want {
+			t.Errorf("romanizeJapanese(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestRomanizeKoreanRevisedRomanization(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"사랑해", "saranghae"},
+		{"안녕하세요", "annyeonghaseyo"},
+		{"밥", "bap"},
+		// A final consonant moves onto a following silent ㅇ.
+		{"한국어", "hangugeo"},
+		{"음악", "eumak"},
+		{"읽어", "ilgeo"},
+		{"없어", "eopseo"},
+		{"좋아요", "joayo"},
+		// ㄹㄹ, ㄹㄴ and ㄴㄹ become "ll".
+		{"신라", "silla"},
+		{"설날", "seollal"},
+		{"빨리", "ppalli"},
+		// Liaison does not cross a space.
+		{"한 아이", "han ai"},
+	}
+
+	for _, test := range tests {
+		if got := romanizeKorean(test.text); got != test.want {
+			t.Errorf("romanizeKorean(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestRomanizeChinesePinyin(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"你好", "nǐ hǎo"},
+		{"我爱你", "wǒ ài nǐ"},
+		{"我愛你", "wǒ ài nǐ"},
+		{"中国，你好！", "zhōng guó, nǐ hǎo!"},
+		{"我们2024年", "wǒ men 2024 nián"},
+		{"龘你好", "龘 nǐ hǎo"},
+	}
+
+	for _, test := range tests {
+		if got := romanizeChinese(test.text); got != test.want {
+			t.Errorf("romanizeChinese(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestRomanizeJapaneseKeepsKanji(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"桜が咲いた", "桜 ga 咲 ita"},
+		{"東京へいこう", "東京 heikou"},
+		{"時々、夢を見る", "時々, 夢 o 見 ru"},
+		{"一緒にいたい", "一緒 niitai"},
+		{"行っちゃった", "行 tchatta"},
+	}
+
+	for _, test := range tests {
+		if got := romanizeJapanese(test.text); got != test.want {
+			t.Errorf("romanizeJapanese(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestRomanizeOutputKeepsLinesWithKanji(t *testing.T) {
+	output := &WhisperOutput{Language: "ja", Segments: []Segment{
+		{Text: " さくら"},
+		{Text: " 桜が咲いた"},
+	}}
+
+	lines, err := romanizeOutput(output, &Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines[0] != "sakura" || lines[1] != "桜 ga 咲 ita" {
+		t.Errorf("lines = %q, want [sakura \"桜 ga 咲 ita\"]", lines)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Kinds of input resolved by resolveSource.
+const (
+	sourceLocal = "local"
+	sourceYTDLP = "yt-dlp"
+	sourceHTTP  = "http"
+	sourceStdin = "stdin"
+)
+
+const httpDownloadTimeout = 30 * time.Minute
+
+var (
+	ErrInputNotFound     = errors.New("input is neither an existing file nor a URL")
+	ErrUnsupportedSource = errors.New("no audio or video found at URL")
+)
+
+// isRemoteURL reports whether input is an http(s) URL. Scheme-less YouTube links count too, since
+// they were accepted before any other site was.
+func isRemoteURL(input string) bool {
+	lower := strings.ToLower(input)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || isYouTubeURL(input)
+}
+
+// ytDLPSupports asks yt-dlp whether it can extract media from url, without downloading anything.
+func ytDLPSupports(url string, verbose bool) bool {
+	cmd := exec.Command("yt-dlp", "--simulate", "--no-playlist", "--quiet", "--no-warnings", url)
+	if verbose {
+		cmd.Stdout = uiWriter
+		cmd.Stderr = os.Stderr
+	}
+	return cmd.Run() == nil
+}
+
+// resolveSource decides how input is fetched. "-" reads stdin. Local paths must exist. YouTube links go straight to
+// yt-dlp; any other http(s) URL is checked with `yt-dlp --simulate` first, so sites such as SoundCloud,
+// Bandcamp and Vimeo use yt-dlp and plain media links it rejects are downloaded over HTTP instead.
+func resolveSource(input string, verbose bool) (string, error) {
+	if input == stdioPath {
+		return sourceStdin, nil
+	}
+
+	if !isRemoteURL(input) {
+		if _, err := os.Stat(input); err != nil {
+			return "", newError("open input", fmt.Errorf("%w: %s", ErrInputNotFound, input))
+		}
+		return sourceLocal, nil
+	}
+
+	if isYouTubeURL(input) {
+		return sourceYTDLP, nil
+	}
+
+	step("Checking URL with yt-dlp...")
+	if ytDLPSupports(input, verbose) {
+		return sourceYTDLP, nil
+	}
+	info("yt-dlp cannot extract this URL, trying a direct download")
+	return sourceHTTP, nil
+}
+
+// isMediaContentType reports whether an HTTP Content-Type can hold audio. Servers often label
+// media files as generic binary data, so that is accepted too.
+func isMediaContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.HasPrefix(mediaType, "audio/") || strings.HasPrefix(mediaType, "video/") ||
+		mediaType == "application/octet-stream" || mediaType == "application/ogg" || mediaType == ""
+}
+
+// downloadFileName picks a local name for a direct download: the last path segment of the URL, with
+// an extension derived from the Content-Type when the URL has none.
+func downloadFileName(rawURL, contentType string) string {
+	name := "audio"
+	if parsedURL, err := url.Parse(rawURL); err == nil {
+		if base := path.Base(parsedURL.Path); base != "/" && base != "." {
+			name = safeFileName(base)
+		}
+	}
+
+	if filepath.Ext(name) == "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) > 0 {
+			name += extensions[0]
+		}
+	}
+	return name
+}
+
+// downloadHTTPAudio fetches a plain media URL into a temporary directory and returns the file path.
+// Web pages and other non-media responses are rejected rather than handed to ffmpeg.
+func downloadHTTPAudio(rawURL string) (string, error) {
+	download("Downloading media file...")
+
+	client := &http.Client{Timeout: httpDownloadTimeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return "", newError("download media file", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", newError("download media file", fmt.Errorf("%w: %s returned %s", ErrUnsupportedSource, rawURL, resp.Status))
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !isMediaContentType(contentType) {
+		return "", newError("download media file", fmt.Errorf("%w: %s is %s", ErrUnsupportedSource, rawURL, contentType))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "echowave-*")
+	if err != nil {
+		return "", newError("create temp directory", err)
+	}
+
+	audioPath := filepath.Join(tmpDir, downloadFileName(rawURL, contentType))
+	out, err := os.Create(audioPath)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", newError("create download file", err)
+	}
+	_, err = io.Copy(out, resp.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", newError("download media file", err)
+	}
+
+	success("Media download completed")
+	return audioPath, nil
+}
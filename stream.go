@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// stdioPath is the input and -output value that stands for stdin and stdout, as in
+// `ffmpeg -i song.flac -f wav - | echowave -output=- - > song.lrc`.
+const stdioPath = "-"
+
+var (
+	ErrNoStdin       = errors.New("nothing is piped into stdin; pipe audio into echowave to read it with \"-\"")
+	ErrStreamFormats = errors.New("-output=- streams exactly one format")
+	ErrStreamOption  = errors.New("option has no effect on the streamed format")
+)
+
+// streamOptionFormats lists the options that add output formats and the formats they affect. A stream
+// holds only the -format choice, so these options add nothing and must go with one of their formats.
+var streamOptionFormats = []struct {
+	Flag    string
+	Formats []string
+}{
+	{"-enhanced-lrc", []string{"elrc"}},
+	{"-romanize", []string{"romanized", "ttml"}},
+	{"-translate", []string{"bilingual", "translated"}},
+}
+
+// isStdoutOutput reports whether the transcript goes to stdout instead of files.
+func isStdoutOutput(config *Config) bool {
+	return config.Output == stdioPath
+}
+
+// validateStreamFormats checks that -output=- has a single format to write, since several
+// formats cannot share one stream.
+func validateStreamFormats(formats []string) error {
+	if len(formats) != 1 {
+		return fmt.Errorf("%w, got %d", ErrStreamFormats, len(formats))
+	}
+	return nil
+}
+
+// validateStreamOptions rejects options that would add a format to a -output=- run, where the single
+// streamed format is the one given with -format. enabled holds the options that are set.
+func validateStreamOptions(format string, enabled map[string]bool) error {
+	for _, option := range streamOptionFormats {
+		if enabled[option.Flag] && !slices.Contains(option.Formats, format) {
+			return fmt.Errorf("%w: %s with -format=%s; stream -format=%s instead", ErrStreamOption, option.Flag, format, strings.Join(option.Formats, " or -format="))
+		}
+	}
+	return nil
+}
+
+// spoolStdin copies audio piped into stdin to a temporary file, since the backends and ffmpeg
+// filters need a seekable file. Returns the file path; the caller removes its directory.
+func spoolStdin() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return "", newError("read stdin", ErrNoStdin)
+	}
+
+	step("Reading audio from stdin...")
+	tmpDir, err := os.MkdirTemp("", "echowave-*")
+	if err != nil {
+		return "", newError("create temp directory", err)
+	}
+
+	audioPath := filepath.Join(tmpDir, "stdin")
+	out, err := os.Create(audioPath)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", newError("create stdin file", err)
+	}
+	size, err := io.Copy(out, os.Stdin)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size == 0 {
+		err = errors.New("no data")
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", newError("read stdin", err)
+	}
+
+	success(fmt.Sprintf("Read %.1f MB from stdin", float64(size)/(1<<20)))
+	return audioPath, nil
+}
+
+// streamOutputFormat renders a Whisper JSON or LRC transcription in the single selected format
+// straight to stdout.
+func streamOutputFormat(inputPath string, config *Config) error {
+	output, err := loadTranscript(inputPath)
+	if err != nil {
+		return err
+	}
+	config = romanizationConfig(output, config)
+	if len(config.Formats) == 0 {
+		// romanizationConfig dropped the only format because the transcript's language cannot be romanized.
+		return newError("render output", fmt.Errorf("%w: %s, so there is nothing to stream", ErrRomanizationUnsupported, firstNonEmpty(transcriptLanguage(output, config), "unknown")))
+	}
+
+	format, ok := findOutputFormat(config.Formats[0])
+	if !ok {
+		return newError("render output", fmt.Errorf("%w: %s", ErrUnsupportedOutputFormat, config.Formats[0]))
+	}
+	step("Writing " + format.Label + " to stdout...")
+	if err := format.Write(os.Stdout, output, config); err != nil {
+		return newError("write "+format.Label+" to stdout", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStreamOutputFormatRejectsUnromanizableLanguage(t *testing.T) {
+	jsonPath := filepath.Join(t.TempDir(), "song.json")
+	if err := os.WriteFile(jsonPath, []byte(whisperDocument), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := &Config{Output: stdioPath, Formats: []string{"romanized"}, Romanize: true, Language: autoLanguage}
+
+	err := streamOutputFormat(jsonPath, config)
+
+	var echoErr *EchoWaveError
+	if !errors.As(err, &echoErr) || !errors.Is(err, ErrRomanizationUnsupported) {
+		t.Fatalf("err = %v, want an EchoWaveError wrapping %v", err, ErrRomanizationUnsupported)
+	}
+	if len(config.Formats) != 1 {
+		t.Error("streaming modified the caller's formats")
+	}
+}
+
+func TestValidateStreamOptions(t *testing.T) {
+	tests := []struct {
+		format  string
+		enabled map[string]bool
+		valid   bool
+	}{
+		{"lrc", nil, true},
+		{"elrc", map[string]bool{"-enhanced-lrc": true}, true},
+		{"lrc", map[string]bool{"-enhanced-lrc": true}, false},
+		{"romanized", map[string]bool{"-romanize": true}, true},
+		{"ttml", map[string]bool{"-romanize": true}, true},
+		{"srt", map[string]bool{"-romanize": true}, false},
+		{"bilingual", map[string]bool{"-translate": true}, true},
+		{"translated", map[string]bool{"-translate": true}, true},
+		{"vtt", map[string]bool{"-translate": true}, false},
+		{"srt", map[string]bool{"-translate": false}, true},
+	}
+
+	for _, test := range tests {
+		err := validateStreamOptions(test.format, test.enabled)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("validateStreamOptions(%s, %v) = %v, want valid %v", test.format, test.enabled, err, test.valid)
+		}
+		if err != nil && !errors.Is(err, ErrStreamOption) {
+			t.Errorf("error %v does not wrap %v", err, ErrStreamOption)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	defaultCueDuration  = 3.0
+	subtitleLineLength  = 42
+	millisecondsPerUnit = 1000
+	secondsPerHour      = 3600
+)
+
+// segmentEnd computes the end time of the segment at index i for formats that need explicit cue ranges.
+// Prefers the last word's end time, then Whisper's segment end, then the next segment's start,
+// and finally a fixed default duration. The result never overlaps the following segment.
+func segmentEnd(segments []Segment, i int) float64 {
+	segment := segments[i]
+	end := 0.0
+
+	if n := len(segment.Words); n > 0 {
+		end = segment.Words[n-1].End
+	}
+	if end <= segment.Start {
+		end = segment.End
+	}
+
+	hasNext := i+1 < len(segments)
+	if end <= segment.Start && hasNext {
+		end = segments[i+1].Start
+	}
+	if end <= segment.Start {
+		end = segment.Start + defaultCueDuration
+	}
+
+	if hasNext && end > segments[i+1].Start && segments[i+1].Start > segment.Start {
+		end = segments[i+1].Start
+	}
+
+	return end
+}
+
+// splitTimestamp breaks floating-point seconds into hours, minutes, seconds and milliseconds
+// with rounding applied at millisecond precision so values never display as 60 seconds.
+func splitTimestamp(seconds float64) (int, int, int, int) {
+	if seconds < 0 {
+		seconds = 0
+	}
+	total := int(seconds*millisecondsPerUnit + 0.5)
+	ms := total % millisecondsPerUnit
+	total /= millisecondsPerUnit
+	return total / secondsPerHour, (total % secondsPerHour) / secondsPerMinute, total % secondsPerMinute, ms
+}
+
+// secondsToSRTTimestamp converts floating-point seconds to SubRip format HH:MM:SS,mmm.
+func secondsToSRTTimestamp(seconds float64) string {
+	h, m, s, ms := splitTimestamp(seconds)
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, ms)
+}
+
+// secondsToVTTTimestamp converts floating-point seconds to WebVTT format HH:MM:SS.mmm.
+func secondsToVTTTimestamp(seconds float64) string {
+	h, m, s, ms := splitTimestamp(seconds)
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
+
+// wrapSubtitleText greedily wraps text into lines no longer than subtitleLineLength characters.
+// Words longer than the limit are kept intact on their own line.
+func wrapSubtitleText(text string) string {
+	words := strings.Fields(text)
+	var lines []string
+	var current strings.Builder
+
+	for _, word := range words {
+		if current.Len() > 0 && len([]rune(current.String()))+1+len([]rune(word)) > subtitleLineLength {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// writeSRT renders segments as numbered SubRip cues with start --> end timing and wrapped text.
+// Empty segments are skipped and cue numbers stay contiguous.
+func writeSRT(w io.Writer, output *WhisperOutput) error {
+	cue := 0
+	for i, segment := range output.Segments {
+		text := wrapSubtitleText(segment.Text)
+		if text == "" {
+			continue
+		}
+		cue++
+		block := fmt.Sprintf("%d\n%s --> %s\n%s\n\n", cue,
+			secondsToSRTTimestamp(segment.Start), secondsToSRTTimestamp(segmentEnd(output.Segments, i)), text)
+		if _, err := io.WriteString(w, block); err != nil {
+			return newError("write SRT content", err)
+		}
+	}
+	return nil
+}
+
+// writeVTT renders segments as a WebVTT document with numbered cues and wrapped text.
+// Empty segments are skipped and cue identifiers stay contiguous.
+func writeVTT(w io.Writer, output *WhisperOutput) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return newError("write WebVTT header", err)
+	}
+
+	cue := 0
+	for i, segment := range output.Segments {
+		text := wrapSubtitleText(segment.Text)
+		if text == "" {
+			continue
+		}
+		cue++
+		block := fmt.Sprintf("%d\n%s --> %s\n%s\n\n", cue,
+			secondsToVTTTimestamp(segment.Start), secondsToVTTTimestamp(segmentEnd(output.Segments, i)), text)
+		if _, err := io.WriteString(w, block); err != nil {
+			return newError("write WebVTT content", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Values accepted by -timestamps.
+const (
+	timestampsOriginal = "original"
+	timestampsClip     = "clip"
+)
+
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// durationPattern matches YouTube-style offsets such as 1h2m3s, 2m or 45s.
+var durationPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+(?:\.\d+)?)s)?$`)
+
+// parseTimeOffset converts a time given as seconds ("90"), clock time ("1:30", "1:02:03.5") or a
+// YouTube-style duration ("1m30s") into seconds.
+func parseTimeOffset(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, ":") {
+		seconds := 0.0
+		for _, part := range strings.Split(value, ":") {
+			number, err := strconv.ParseFloat(part, 64)
+			if err != nil || number < 0 {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidTimeRange, value)
+			}
+			seconds = seconds*60 + number
+		}
+		return seconds, nil
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	match := durationPattern.FindStringSubmatch(value)
+	if value == "" || match == nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeRange, value)
+	}
+	hours, _ := strconv.ParseFloat(firstNonEmpty(match[1], "0"), 64)
+	minutes, _ := strconv.ParseFloat(firstNonEmpty(match[2], "0"), 64)
+	seconds, _ := strconv.ParseFloat(firstNonEmpty(match[3], "0"), 64)
+	return hours*secondsPerHour + minutes*60 + seconds, nil
+}
+
+// youTubeStartTime returns the t parameter of a YouTube URL, or an empty string when there is none.
+func youTubeStartTime(input string) string {
+	if !isYouTubeURL(input) {
+		return ""
+	}
+	parsedURL, err := url.Parse(input)
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Query().Get("t")
+}
+
+// parseTimeRange resolves -start and -end. An end of zero means the end of the track.
+// A URL's t parameter is applied later, per input, by inputTimeRange.
+func parseTimeRange(start, end string) (float64, float64, error) {
+	var startSeconds, endSeconds float64
+	var err error
+	if start != "" {
+		if startSeconds, err = parseTimeOffset(start); err != nil {
+			return 0, 0, err
+		}
+	}
+	if end != "" {
+		if endSeconds, err = parseTimeOffset(end); err != nil {
+			return 0, 0, err
+		}
+		if endSeconds <= startSeconds {
+			return 0, 0, fmt.Errorf("%w: end %s is not after start %s", ErrInvalidTimeRange, formatSeconds(endSeconds), formatSeconds(startSeconds))
+		}
+	}
+	return startSeconds, endSeconds, nil
+}
+
+// inputTimeRange starts a YouTube input at its t parameter when -start is not set. It runs for every
+// input, so in batch and playlist runs each URL keeps its own start time. The returned config is a copy
+// when the start changes, and config itself otherwise.
+func inputTimeRange(input string, config *Config) (*Config, error) {
+	t := youTubeStartTime(input)
+	if t == "" || config.Start > 0 {
+		return config, nil
+	}
+
+	start, err := parseTimeOffset(t)
+	if err != nil {
+		return nil, err
+	}
+	if config.End > 0 && config.End <= start {
+		return nil, fmt.Errorf("%w: end %s is not after the URL's start %s", ErrInvalidTimeRange, formatSeconds(config.End), formatSeconds(start))
+	}
+
+	itemConfig := *config
+	itemConfig.Start = start
+	return &itemConfig, nil
+}
+
+// validateTimestampsMode checks the -timestamps option.
+func validateTimestampsMode(mode string) error {
+	if mode != timestampsOriginal && mode != timestampsClip {
+		return fmt.Errorf("%w: -timestamps must be %s or %s, got %q", ErrInvalidTimeRange, timestampsOriginal, timestampsClip, mode)
+	}
+	return nil
+}
+
+// formatSeconds renders seconds compactly for command arguments and messages.
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
+
+// hasTimeRange reports whether -start, -end or a URL t parameter limits the transcribed audio.
+func hasTimeRange(config *Config) bool {
+	return config.Start > 0 || config.End > 0
+}
+
+// downloadSection returns the yt-dlp --download-sections value for the configured range.
+func downloadSection(config *Config) string {
+	end := "inf"
+	if config.End > 0 {
+		end = formatSeconds(config.End)
+	}
+	return "*" + formatSeconds(config.Start) + "-" + end
+}
+
+// trimAudio cuts the configured range out of a local file with ffmpeg. The clip is written as FLAC under
+// the original file name in a temporary directory, so it stays lossless, keeps the container tags and
+// produces the same output names. The returned cleanup removes the clip.
+func trimAudio(audioPath string, config *Config) (string, func(), error) {
+	processing("Trimming audio to the requested range...")
+
+	tmpDir, err := os.MkdirTemp("", "echowave-clip-*")
+	if err != nil {
+		return "", nil, newError("create temp directory", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			warning(fmt.Sprintf("Failed to cleanup temp files: %v", err))
+		}
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
+	clipPath := filepath.Join(tmpDir, baseName+".flac")
+
+	args := []string{"-y", "-loglevel", "error", "-ss", formatSeconds(config.Start), "-i", audioPath}
+	if config.End > 0 {
+		args = append(args, "-t", formatSeconds(config.End-config.Start))
+	}
+	args = append(args, "-vn", "-map_metadata", "0", "-c:a", "flac", clipPath)
+
+	cmd := exec.Command("ffmpeg", args...)
+	if config.Verbose {
+		cmd.Stdout = uiWriter
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return "", nil, newError("trim audio", err)
+	}
+
+	success("Audio trimmed")
+	return clipPath, cleanup, nil
+}
+
+// shiftTranscript moves every segment and word by offset seconds, turning clip-relative times into
+// times on the original track. The backend's seek offsets refer to the clip, so they are dropped.
+func shiftTranscript(output *WhisperOutput, offset float64) {
+	for i := range output.Segments {
+		segment := &output.Segments[i]
+		segment.Start += offset
+		segment.End += offset
+		segment.Raw = segment.Raw.without(seekField)
+		for j := range segment.Words {
+			segment.Words[j].Start += offset
+			segment.Words[j].End += offset
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInputTimeRangeIsPerInput(t *testing.T) {
+	config := &Config{}
+
+	first, err := inputTimeRange("https://www.youtube.com/watch?v=abc&t=95", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := inputTimeRange("https://www.youtube.com/watch?v=def", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Start != 95 || second.Start != 0 || config.Start != 0 {
+		t.Errorf("starts = %v, %v (shared config %v), want 95, 0 and 0", first.Start, second.Start, config.Start)
+	}
+
+	explicit, err := inputTimeRange("https://youtu.be/abc?t=1m30s", &Config{Start: 10})
+	if err != nil || explicit.Start != 10 {
+		t.Errorf("-start was overridden by the URL: %v, %v", explicit, err)
+	}
+
+	if _, err := inputTimeRange("https://youtu.be/abc?t=120", &Config{End: 60}); !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("end before the URL's start: err = %v, want %v", err, ErrInvalidTimeRange)
+	}
+}
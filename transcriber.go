@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnsupportedBackend = errors.New("unsupported transcription backend")
+
+// Transcriber is a speech-to-text backend that turns an audio file into the shared WhisperOutput model.
+// Each backend declares the external tools it needs so only those tools are checked before running,
+// and the backend-specific inputs that change its output so the transcription cache can key on them.
+type Transcriber interface {
+	Name() string
+	Dependencies(config *Config) []Dependency
+	CacheKey(config *Config) []string
+	Transcribe(audioPath string, config *Config) (*WhisperOutput, error)
+}
+
+var transcribers = []Transcriber{
+	whisperTranscriber{},
+	whisperCppTranscriber{},
+	fasterWhisperTranscriber{},
+	openAITranscriber{},
+}
+
+// findTranscriber looks up a registered transcription backend by its command-line name.
+func findTranscriber(name string) (Transcriber, error) {
+	for _, transcriber := range transcribers {
+		if transcriber.Name() == name {
+			return transcriber, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s (supported: %s)", ErrUnsupportedBackend, name, strings.Join(transcriberNames(), ", "))
+}
+
+// transcriberNames returns the command-line names of all registered transcription backends.
+func transcriberNames() []string {
+	names := make([]string, len(transcribers))
+	for i, transcriber := range transcribers {
+		names[i] = transcriber.Name()
+	}
+	return names
+}
+
+// whisperTranscriber drives the Python openai-whisper CLI.
+type whisperTranscriber struct{}
+
+func (whisperTranscriber) Name() string {
+	return "whisper"
+}
+
+func (whisperTranscriber) Dependencies(_ *Config) []Dependency {
+	return []Dependency{whisperDependency}
+}
+
+// CacheKey identifies the whisper executable, whose installed version decides the output.
+func (whisperTranscriber) CacheKey(_ *Config) []string {
+	return []string{commandIdentity(whisperDependency.Command)}
+}
+
+// Transcribe runs the whisper CLI into a temporary directory and loads the JSON it produces.
+func (whisperTranscriber) Transcribe(audioPath string, config *Config) (*WhisperOutput, error) {
+	tmpDir, err := os.MkdirTemp("", "echowave-whisper-*")
+	if err != nil {
+		return nil, newError("create temp directory", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := runWhisper(audioPath, config.Model, config.Language, config.Task, config.Prompt, tmpDir); err != nil {
+		return nil, err
+	}
+
+	audioBaseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
+	return loadWhisperOutput(filepath.Join(tmpDir, audioBaseName+".json"))
+}
@@ -34,24 +34,57 @@ type Word struct {
 // Used for parsing Whisper JSON output and generating LRC timestamps.
 type Segment struct {
 	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
 	Text        string  `json:"text"`
 	AvgLogprob  float64 `json:"avg_logprob"`
 	Confidence  float64 `json:"confidence"`
 	Words       []Word  `json:"words"`
+	Agent       string  `json:"agent,omitempty"`
+	Translation string  `json:"translation,omitempty"`
+
+	// NoSpeechProb is Whisper's probability that the segment's audio holds no speech.
+	NoSpeechProb float64 `json:"no_speech_prob,omitempty"`
+	// Hallucination is why the segment was flagged as a likely hallucination with -hallucinations=mark.
+	Hallucination string `json:"hallucination,omitempty"`
+
+	// Interpolated marks a line of supplied lyrics that matched no recognized words and was timed
+	// by interpolation between its neighbours.
+	Interpolated bool `json:"interpolated,omitempty"`
+
+	// Raw keeps the backend's own segment fields, such as tokens and temperature, for the .json output.
+	Raw rawFields `json:"-"`
 }
 
 // WhisperOutput represents the complete JSON response from OpenAI Whisper transcription.
-// Contains an array of text segments with precise timing for lyrics generation.
+// Contains an array of text segments with precise timing for lyrics generation, plus the
+// track metadata EchoWave records under the "echowave" key.
 type WhisperOutput struct {
-	Segments []Segment `json:"segments"`
+	Text                string              `json:"text,omitempty"`
+	Language            string              `json:"language,omitempty"`
+	LanguageProbability float64             `json:"language_probability,omitempty"`
+	Segments            []Segment           `json:"segments"`
+	Metadata            *TranscriptMetadata `json:"echowave,omitempty"`
+
+	// Raw keeps the backend's own top-level fields for the .json output.
+	Raw rawFields `json:"-"`
 }
 
 // secondsToLRCTimestamp converts floating-point seconds to LRC synchronized lyric format [MM:SS.XX].
 // Used for creating timestamps compatible with media players that support LRC files.
 func secondsToLRCTimestamp(seconds float64) string {
-	minutes := int(seconds) / secondsPerMinute
-	sec := seconds - float64(minutes*secondsPerMinute)
-	return fmt.Sprintf("[%02d:%05.2f]", minutes, sec)
+	return "[" + formatLRCTime(seconds) + "]"
+}
+
+// formatLRCTime renders seconds as MM:SS.XX. The value is rounded to centiseconds before it is split,
+// so 59.996 becomes 01:00.00 rather than the invalid 00:60.00.
+func formatLRCTime(seconds float64) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	total := int(seconds*centisecondsPerUnit + 0.5)
+	cs := total % centisecondsPerUnit
+	total /= centisecondsPerUnit
+	return fmt.Sprintf("%02d:%02d.%02d", total/secondsPerMinute, total%secondsPerMinute, cs)
 }
 
 // getConfidenceColor returns the appropriate color based on confidence level.
@@ -69,33 +102,24 @@ func getConfidenceColor(confidence float64) string {
 // Words are colored based on their confidence scores for easy identification of uncertain transcription.
 func displayHeatmap(jsonPath string) error {
 	header("Transcription Accuracy Heatmap")
-	
-	data, err := os.ReadFile(jsonPath)
-	if err != nil {
-		return newError("read JSON file for heatmap", err)
-	}
-
-	var output WhisperOutput
-	if err := json.Unmarshal(data, &output); err != nil {
-		return newError("parse JSON for heatmap", err)
-	}
 
-	if len(output.Segments) == 0 {
-		return newError("display heatmap", ErrNoSegmentsFound)
+	output, err := loadTranscript(jsonPath)
+	if err != nil {
+		return err
 	}
 
 	info("Legend: " + colorize("High confidence (>0.8)", BrightGreen) + " | " + 
 		colorize("Medium confidence (0.5-0.8)", BrightYellow) + " | " + 
 		colorize("Low confidence (<0.5)", BrightRed))
-	fmt.Println()
+	fmt.Fprintln(uiWriter)
 
 	for _, segment := range output.Segments {
-		fmt.Printf("%s ", colorize(secondsToLRCTimestamp(segment.Start), MutedColor))
+		fmt.Fprintf(uiWriter, "%s ", colorize(secondsToLRCTimestamp(segment.Start), MutedColor))
 		
 		if len(segment.Words) > 0 {
 			for _, word := range segment.Words {
 				color := getConfidenceColor(word.Probability)
-				fmt.Printf("%s ", colorize(word.Word, color))
+				fmt.Fprintf(uiWriter, "%s ", colorize(word.Word, color))
 			}
 		} else {
 			segmentConfidence := segment.Confidence
@@ -106,12 +130,12 @@ func displayHeatmap(jsonPath string) error {
 				}
 			}
 			color := getConfidenceColor(segmentConfidence)
-			fmt.Printf("%s ", colorize(strings.TrimSpace(segment.Text), color))
+			fmt.Fprintf(uiWriter, "%s ", colorize(strings.TrimSpace(segment.Text), color))
 		}
-		fmt.Println()
+		fmt.Fprintln(uiWriter)
 	}
 
-	fmt.Println()
+	fmt.Fprintln(uiWriter)
 	success("Heatmap display completed")
 	return nil
 }
@@ -129,8 +153,9 @@ func validateWhisperModel(model string) bool {
 
 // runWhisper executes OpenAI Whisper AI transcription engine with audio file and model configuration.
 // Outputs JSON transcription with word-level timestamps to specified directory.
+// The language flag is omitted for "auto" so Whisper detects it and records it in the JSON.
 // Stdout/stderr are inherited to show real-time transcription progress.
-func runWhisper(audioPath, model, language, outputDir string) error {
+func runWhisper(audioPath, model, language, task, prompt, outputDir string) error {
 	processing("Running Whisper transcription...")
 
 	if !validateWhisperModel(model) {
@@ -139,10 +164,21 @@ func runWhisper(audioPath, model, language, outputDir string) error {
 
 	step("Model: " + model + ", Language: " + language)
 
-	cmd := exec.Command("whisper", audioPath, "--model", model, "--language", language,
-		"--output_format", "json", "--word_timestamps", "True", "--temperature", "0", "--output_dir", outputDir)
+	args := []string{audioPath, "--model", model}
+	if language != autoLanguage {
+		args = append(args, "--language", language)
+	}
+	if task == taskTranslate {
+		args = append(args, "--task", taskTranslate)
+	}
+	if prompt != "" {
+		args = append(args, "--initial_prompt", prompt)
+	}
+	args = append(args, "--output_format", "json", "--word_timestamps", "True", "--temperature", "0", "--output_dir", outputDir)
+
+	cmd := exec.Command("whisper", args...)
 
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = uiWriter
 	cmd.Stderr = os.Stderr
 
 	err := cmd.Run()
@@ -154,92 +190,173 @@ func runWhisper(audioPath, model, language, outputDir string) error {
 	return nil
 }
 
-// convertJSONToLRC parses Whisper's JSON transcription output and generates LRC synchronized lyrics file.
-// Each segment's start timestamp is converted to LRC format with corresponding text.
-// Validates JSON structure and ensures segments exist before processing.
-func convertJSONToLRC(jsonPath, lrcPath string) error {
-	step("Converting transcription to LRC format...")
-
+// loadWhisperOutput reads and parses a Whisper JSON transcription from disk.
+// Validates JSON structure and ensures at least one segment exists before returning.
+func loadWhisperOutput(jsonPath string) (*WhisperOutput, error) {
 	data, err := os.ReadFile(jsonPath)
 	if err != nil {
-		return newError("read JSON file", err)
+		return nil, newError("read JSON file", err)
 	}
 
 	var output WhisperOutput
 	if err := json.Unmarshal(data, &output); err != nil {
-		return newError("parse JSON", err)
+		return nil, newError("parse JSON", err)
 	}
 
 	if len(output.Segments) == 0 {
-		return newError("process transcription", ErrNoSegmentsFound)
+		return nil, newError("process transcription", ErrNoSegmentsFound)
 	}
 
-	lrcFile, err := os.Create(lrcPath)
+	return &output, nil
+}
+
+// writeWhisperOutput saves a transcription as JSON in the Whisper output layout, including EchoWave's metadata.
+// Backend fields EchoWave does not model are carried in Raw and written back unchanged.
+func writeWhisperOutput(jsonPath string, output *WhisperOutput) error {
+	data, err := json.Marshal(output)
 	if err != nil {
-		return newError("create LRC file", err)
+		return newError("encode JSON", err)
 	}
-	defer func() {
-		if err := lrcFile.Close(); err != nil {
-			fmt.Printf("Warning: failed to close LRC file: %v\n", err)
-		}
-	}()
+	if err := os.WriteFile(jsonPath, data, 0o644); err != nil {
+		return newError("write JSON file", err)
+	}
+	return nil
+}
 
-	for _, segment := range output.Segments {
-		line := fmt.Sprintf("%s %s\n", secondsToLRCTimestamp(segment.Start), strings.TrimSpace(segment.Text))
-		if _, err := lrcFile.WriteString(line); err != nil {
-			return newError("write LRC content", err)
-		}
+// loadTranscript loads a transcription from either a Whisper JSON file or an LRC file, chosen by extension.
+// Problems found while parsing LRC files are reported as warnings rather than failing the load.
+func loadTranscript(path string) (*WhisperOutput, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".lrc") {
+		return loadWhisperOutput(path)
 	}
 
-	file("LRC file created: " + lrcPath)
-	return nil
+	doc, err := parseLRCFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range doc.Problems {
+		warning(filepath.Base(path) + ": " + problem)
+	}
+	return doc.Output, nil
 }
 
-// generateTranscription manages the complete audio-to-lyrics pipeline using Whisper AI.
-// Creates output directory, runs transcription, handles file naming, and generates both JSON and LRC formats.
+// generateTranscription manages the complete audio-to-lyrics pipeline using the selected transcription backend.
+// Creates output directory, runs transcription, handles file naming, and generates JSON plus every selected output format.
 // Automatically resolves output file paths and manages temporary file cleanup.
-func generateTranscription(audioPath string, config *Config) {
+// Errors are returned rather than exiting so batch runs can carry on with the next item.
+// With -output=- the JSON goes to a temporary directory and the single selected format is written to stdout.
+// Track metadata is read from metadataPath, the untrimmed source, so a -start/-end clip reports the full duration.
+func generateTranscription(audioPath, metadataPath string, config *Config) error {
 	step("Setting up output directory...")
 	if err := os.MkdirAll(config.OutputDir, 0o750); err != nil {
-		exitWithError(newError("create output directory", err))
+		return newError("create output directory", err)
+	}
+
+	transcriber, err := findTranscriber(config.Backend)
+	if err != nil {
+		return newError("select transcription backend", err)
+	}
+
+	var lyrics []lyricLine
+	if config.Lyrics != "" {
+		if lyrics, err = readLyricsFile(config.Lyrics); err != nil {
+			return newError("read lyrics file", err)
+		}
+	}
+
+	audio := newCachedAudio(audioPath, config)
+	defer audio.close()
+
+	if config.Prompt != "" {
+		step(fmt.Sprintf("Prompting the backend with %d characters of context", len([]rune(config.Prompt))))
+	}
+
+	output, err := audio.transcribe(transcriber, config)
+	if err != nil {
+		return err
+	}
+
+	resolveTranscriptLanguage(output, config)
+	if config.Language == autoLanguage {
+		reportDetectedLanguage(output, transcriber.Name())
+	}
+
+	hallucinations, err := filterHallucinations(output, config.Hallucinations)
+	reportHallucinations(hallucinations, config.Hallucinations)
+	if err != nil {
+		return newError("filter hallucinations", err)
+	}
+
+	if lyrics != nil {
+		step("Aligning supplied lyrics to the transcription...")
+		if err := alignLyrics(output, lyrics); err != nil {
+			return newError("align lyrics", err)
+		}
+	}
+
+	if config.Translate {
+		if err := addTranslation(audio, transcriber, output, config); err != nil {
+			return err
+		}
+	}
+
+	if config.Start > 0 && config.Timestamps == timestampsOriginal {
+		shiftTranscript(output, config.Start)
 	}
 
 	var base string
-	if config.Output != "" {
-		base = filepath.Join(config.OutputDir, config.Output)
+	if isStdoutOutput(config) {
+		// The JSON is still needed to render from, but only the streamed format is kept.
+		tmpDir, err := os.MkdirTemp("", "echowave-*")
+		if err != nil {
+			return newError("create temp directory", err)
+		}
+		defer removeTempDir(tmpDir)()
+		base = filepath.Join(tmpDir, "transcript")
+	} else if config.Output != "" {
+		base = filepath.Join(config.OutputDir, strings.ReplaceAll(config.Output, "{lang}", firstNonEmpty(output.Language, "und")))
 	} else {
 		audioBaseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
 		base = filepath.Join(config.OutputDir, audioBaseName)
 	}
+	if err := os.MkdirAll(filepath.Dir(base), 0o750); err != nil {
+		return newError("create output directory", err)
+	}
 
 	jsonPath := base + ".json"
-	lrcPath := base + ".lrc"
 
-	if err := runWhisper(audioPath, config.Model, config.Language, config.OutputDir); err != nil {
-		exitWithError(err)
-	}
+	step("Reading track metadata...")
+	output.Metadata = readTrackMetadata(metadataPath)
+	output.Metadata.Prompt = config.Prompt
 
-	actualJSONPath := jsonPath
-	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
-		audioBaseName := strings.TrimSuffix(filepath.Base(audioPath), filepath.Ext(audioPath))
-		whisperJSONPath := filepath.Join(config.OutputDir, audioBaseName+".json")
-		if _, err := os.Stat(whisperJSONPath); err == nil {
-			actualJSONPath = whisperJSONPath
-		}
+	if err := writeWhisperOutput(jsonPath, output); err != nil {
+		return err
 	}
-
-	if err := convertJSONToLRC(actualJSONPath, lrcPath); err != nil {
-		exitWithError(err)
+	if isStdoutOutput(config) {
+		if err := streamOutputFormat(jsonPath, config); err != nil {
+			return err
+		}
+	} else {
+		file("JSON file created: " + jsonPath)
+		if err := renderOutputFormats(jsonPath, base, nil, config); err != nil {
+			return err
+		}
 	}
 
 	if config.Heatmap {
-		fmt.Println()
-		if err := displayHeatmap(actualJSONPath); err != nil {
+		fmt.Fprintln(uiWriter)
+		if err := displayHeatmap(jsonPath); err != nil {
 			warning("Failed to display heatmap: " + err.Error())
 		}
 	}
 
-	fmt.Println()
+	fmt.Fprintln(uiWriter)
 	success("Transcription completed successfully!")
-	info("Files saved in: " + config.OutputDir)
+	if !isStdoutOutput(config) {
+		info("Files saved in: " + config.OutputDir)
+	}
+	if summary := hallucinationSummary(hallucinations, config.Hallucinations); summary != "" {
+		warning(summary)
+	}
+	return nil
 }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Whisper tasks. Translation always produces English text.
+const (
+	taskTranscribe = "transcribe"
+	taskTranslate  = "translate"
+)
+
+var ErrNoTranslation = errors.New("transcription has no translated lines")
+
+// overlap returns the length of the intersection of two time ranges, or zero if they do not intersect.
+func overlap(startA, endA, startB, endB float64) float64 {
+	return math.Max(0, math.Min(endA, endB)-math.Max(startA, startB))
+}
+
+// alignTranslation attaches translated text to the original segments by time. Each translated segment is
+// assigned to the original segment it overlaps most (or the one with the nearest start when nothing
+// overlaps), and texts assigned to the same original segment are joined in order.
+func alignTranslation(original, translated []Segment) {
+	if len(original) == 0 {
+		return
+	}
+
+	parts := make([][]string, len(original))
+	for i, t := range translated {
+		text := strings.TrimSpace(t.Text)
+		if text == "" {
+			continue
+		}
+
+		tEnd := segmentEnd(translated, i)
+		best, bestOverlap, bestDistance := 0, 0.0, math.Inf(1)
+		for j := range original {
+			o := overlap(original[j].Start, segmentEnd(original, j), t.Start, tEnd)
+			distance := math.Abs(original[j].Start - t.Start)
+			if o > bestOverlap || (bestOverlap == 0 && o == 0 && distance < bestDistance) {
+				best, bestOverlap, bestDistance = j, o, distance
+			}
+		}
+		parts[best] = append(parts[best], text)
+	}
+
+	for i := range original {
+		original[i].Translation = strings.Join(parts[i], " ")
+	}
+}
+
+// hasTranslation reports whether any segment carries translated text.
+func hasTranslation(output *WhisperOutput) bool {
+	for _, segment := range output.Segments {
+		if segment.Translation != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// addTranslation runs a second Whisper pass with the translate task and aligns the English result
+// onto the original segments. English sources are skipped, and the bilingual and translated formats
+// dropped, since translation would only repeat the lyrics.
+func addTranslation(audio *cachedAudio, transcriber Transcriber, output *WhisperOutput, config *Config) error {
+	if output.Language == "en" {
+		warning("Source language is English, skipping translation")
+		// Clone first: batch items share the backing array of the Formats slice.
+		config.Formats = slices.DeleteFunc(slices.Clone(config.Formats), func(name string) bool {
+			return name == "bilingual" || name == "translated"
+		})
+		if len(config.Formats) == 0 {
+			// Only translation formats were asked for, e.g. -format=bilingual -output=-; the plain lyrics stand in.
+			config.Formats = []string{"lrc"}
+		}
+		return nil
+	}
+
+	header("Translation Pass")
+
+	translateConfig := *config
+	translateConfig.Task = taskTranslate
+	// A prompt in the source language pulls the translate task back towards transcribing.
+	translateConfig.Prompt = ""
+	if output.Language != "" {
+		translateConfig.Language = output.Language
+	}
+
+	translated, err := audio.transcribe(transcriber, &translateConfig)
+	if err != nil {
+		return err
+	}
+
+	// Flagged translations are dropped even with -hallucinations=mark, since they are merged into the
+	// original lines where a mark could not be seen.
+	if config.Hallucinations != hallucinationsOff {
+		if _, err := filterHallucinations(translated, hallucinationsDrop); err != nil {
+			warning("Every translated segment looks hallucinated, skipping translation")
+			return nil
+		}
+	}
+
+	alignTranslation(output.Segments, translated.Segments)
+	success(fmt.Sprintf("Aligned %d translated segments with %d original lines", len(translated.Segments), len(output.Segments)))
+	return nil
+}
+
+// writeBilingualLRC renders each original line followed by its translation under the same timestamp,
+// which players supporting bilingual LRC show as a two-line pair.
+func writeBilingualLRC(w io.Writer, output *WhisperOutput) error {
+	if !hasTranslation(output) {
+		return newError("write bilingual LRC", ErrNoTranslation)
+	}
+	if _, err := io.WriteString(w, lrcHeader(output)); err != nil {
+		return newError("write bilingual LRC header", err)
+	}
+
+	for _, segment := range output.Segments {
+		timestamp := secondsToLRCTimestamp(segment.Start)
+		lines := timestamp + " " + strings.TrimSpace(segment.Text) + "\n"
+		if segment.Translation != "" {
+			lines += timestamp + " " + segment.Translation + "\n"
+		}
+		if _, err := io.WriteString(w, lines); err != nil {
+			return newError("write bilingual LRC content", err)
+		}
+	}
+	return nil
+}
+
+// writeTranslatedLRC renders only the translated lines at the original timestamps.
+// Lines without a translation are omitted and the [la:] tag reflects Whisper's English output.
+func writeTranslatedLRC(w io.Writer, output *WhisperOutput) error {
+	if !hasTranslation(output) {
+		return newError("write translated LRC", ErrNoTranslation)
+	}
+
+	translated := *output
+	translated.Language = "en"
+	if _, err := io.WriteString(w, lrcHeader(&translated)); err != nil {
+		return newError("write translated LRC header", err)
+	}
+
+	for _, segment := range output.Segments {
+		if segment.Translation == "" {
+			continue
+		}
+		line := fmt.Sprintf("%s %s\n", secondsToLRCTimestamp(segment.Start), segment.Translation)
+		if _, err := io.WriteString(w, line); err != nil {
+			return newError("write translated LRC content", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	ttmlNamespace         = "http://www.w3.org/ns/ttml"
+	ttmlMetadataNamespace = "http://www.w3.org/ns/ttml#metadata"
+	ttmlITunesNamespace   = "http://music.apple.com/lyric-ttml-internal"
+)
+
+// escapeXML escapes text for safe inclusion in TTML element content and attribute values.
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	if err := xml.EscapeText(&buf, []byte(text)); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// segmentAgent resolves the ttm:agent for a segment, preferring the segment's own agent
+// over the default agent configured for the whole document.
+func segmentAgent(segment Segment, defaultAgent string) string {
+	if segment.Agent != "" {
+		return segment.Agent
+	}
+	return defaultAgent
+}
+
+// ttmlLine renders a single <p> element for a segment with one <span> per timed word.
+// Segments without word timings are written as plain line-timed text, and a romanization,
+// when given, follows as an x-roman span.
+func ttmlLine(segments []Segment, i int, agent, romanized string) string {
+	segment := segments[i]
+	end := segmentEnd(segments, i)
+
+	var line strings.Builder
+	line.WriteString(fmt.Sprintf(`      <p begin="%s" end="%s"`, secondsToVTTTimestamp(segment.Start), secondsToVTTTimestamp(end)))
+	if agent != "" {
+		line.WriteString(fmt.Sprintf(` ttm:agent="%s"`, escapeXML(agent)))
+	}
+	line.WriteString(">")
+
+	var spans []string
+	for _, word := range segment.Words {
+		text := strings.TrimSpace(word.Word)
+		if text == "" {
+			continue
+		}
+		wordEnd := word.End
+		if wordEnd <= word.Start {
+			wordEnd = end
+		}
+		spans = append(spans, fmt.Sprintf(`<span begin="%s" end="%s">%s</span>`,
+			secondsToVTTTimestamp(word.Start), secondsToVTTTimestamp(wordEnd), escapeXML(text)))
+	}
+
+	if len(spans) > 0 {
+		line.WriteString(strings.Join(spans, " "))
+	} else {
+		line.WriteString(escapeXML(strings.TrimSpace(segment.Text)))
+	}
+
+	if romanized != "" {
+		line.WriteString(fmt.Sprintf(`<span ttm:role="x-roman">%s</span>`, escapeXML(romanized)))
+	}
+
+	line.WriteString("</p>\n")
+	return line.String()
+}
+
+// writeTTML renders segments as Apple-style TTML lyrics with <p> lines and per-word <span> timing.
+// Lines carry a ttm:agent attribute when the segment or the -ttml-agent option assigns one, and every
+// agent used is declared in the document head so duet vocals can be marked. With -romanize each line
+// also carries its romanization.
+func writeTTML(w io.Writer, output *WhisperOutput, config *Config) error {
+	var romanized []string
+	if config.Romanize {
+		lines, err := romanizeOutput(output, config)
+		if err != nil {
+			return newError("romanize TTML", err)
+		}
+		romanized = lines
+	}
+
+	timing := "Line"
+	var agents []string
+	seenAgents := make(map[string]bool)
+
+	for _, segment := range output.Segments {
+		if len(segment.Words) > 0 {
+			timing = "Word"
+		}
+		if agent := segmentAgent(segment, config.TTMLAgent); agent != "" && !seenAgents[agent] {
+			seenAgents[agent] = true
+			agents = append(agents, agent)
+		}
+	}
+
+	last := len(output.Segments) - 1
+	begin := output.Segments[0].Start
+	end := segmentEnd(output.Segments, last)
+
+	var doc strings.Builder
+	doc.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	doc.WriteString(fmt.Sprintf(`<tt xmlns="%s" xmlns:ttm="%s" xmlns:itunes="%s" itunes:timing="%s" xml:lang="%s">`+"\n",
+		ttmlNamespace, ttmlMetadataNamespace, ttmlITunesNamespace, timing, escapeXML(firstNonEmpty(transcriptLanguage(output, config), "und"))))
+
+	doc.WriteString("  <head>\n    <metadata>\n")
+	for _, agent := range agents {
+		doc.WriteString(fmt.Sprintf(`      <ttm:agent type="person" xml:id="%s"/>`+"\n", escapeXML(agent)))
+	}
+	doc.WriteString("    </metadata>\n  </head>\n")
+
+	doc.WriteString(fmt.Sprintf(`  <body dur="%s">`+"\n", secondsToVTTTimestamp(end)))
+	doc.WriteString(fmt.Sprintf(`    <div begin="%s" end="%s">`+"\n", secondsToVTTTimestamp(begin), secondsToVTTTimestamp(end)))
+
+	for i, segment := range output.Segments {
+		if strings.TrimSpace(segment.Text) == "" && len(segment.Words) == 0 {
+			continue
+		}
+		roman := ""
+		if romanized != nil {
+			roman = romanized[i]
+		}
+		doc.WriteString(ttmlLine(output.Segments, i, segmentAgent(segment, config.TTMLAgent), roman))
+	}
+
+	doc.WriteString("    </div>\n  </body>\n</tt>\n")
+
+	if _, err := io.WriteString(w, doc.String()); err != nil {
+		return newError("write TTML content", err)
+	}
+	return nil
+}
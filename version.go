@@ -233,13 +233,13 @@ func checkForUpdates() {
 	}
 
 	if isNewerVersion(VERSION, release.TagName) {
-		fmt.Printf("%s %s\n",
+		fmt.Fprintf(uiWriter, "%s %s\n",
 			colorize("🔄 Update available:", InfoColor),
 			colorize(fmt.Sprintf("v%s → %s", VERSION, release.TagName), SuccessColor))
-		fmt.Printf("%s %s\n",
+		fmt.Fprintf(uiWriter, "%s %s\n",
 			colorize("📦 Run", InfoColor),
 			colorize("echowave update", PrimaryColor)+colorize(" to update", InfoColor))
-		fmt.Println()
+		fmt.Fprintln(uiWriter)
 	}
 }
 
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const defaultDemucsModel = "htdemucs"
+
+var (
+	ErrUnsupportedSeparator = errors.New("unsupported vocal separator")
+	ErrVocalStemNotFound    = errors.New("vocal stem not found")
+)
+
+// VocalSeparator is a source-separation tool that extracts the vocal stem from a mixed track.
+// Separate writes the stem into outputDir and returns its path.
+type VocalSeparator struct {
+	Name       string
+	Dependency Dependency
+	Separate   func(audioPath, outputDir string, verbose bool) (string, error)
+}
+
+var vocalSeparators = []VocalSeparator{
+	{
+		Name:       "demucs",
+		Dependency: demucsDependency,
+		Separate:   separateWithDemucs,
+	},
+	{
+		Name:       "audio-separator",
+		Dependency: audioSeparatorDependency,
+		Separate:   separateWithAudioSeparator,
+	},
+}
+
+// findVocalSeparator looks up a registered vocal separator by its command-line name.
+func findVocalSeparator(name string) (VocalSeparator, error) {
+	for _, separator := range vocalSeparators {
+		if separator.Name == name {
+			return separator, nil
+		}
+	}
+	return VocalSeparator{}, fmt.Errorf("%w: %s (supported: %s)", ErrUnsupportedSeparator, name, strings.Join(vocalSeparatorNames(), ", "))
+}
+
+// vocalSeparatorNames returns the command-line names of all registered vocal separators.
+func vocalSeparatorNames() []string {
+	names := make([]string, len(vocalSeparators))
+	for i, separator := range vocalSeparators {
+		names[i] = separator.Name
+	}
+	return names
+}
+
+// runSeparator runs a separation command, showing its output only in verbose mode.
+func runSeparator(name string, args []string, verbose bool) error {
+	cmd := exec.Command(name, args...)
+	if verbose {
+		cmd.Stdout = uiWriter
+		cmd.Stderr = os.Stderr
+	}
+	return cmd.Run()
+}
+
+// findVocalStem returns the single file matching pattern inside the separator's output directory.
+func findVocalStem(pattern string) (string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil || len(matches) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrVocalStemNotFound, pattern)
+	}
+	return matches[0], nil
+}
+
+// separateWithDemucs runs Demucs in two-stem mode, which writes <model>/<track>/vocals.wav.
+func separateWithDemucs(audioPath, outputDir string, verbose bool) (string, error) {
+	args := []string{"--two-stems=vocals", "-n", defaultDemucsModel, "-o", outputDir, audioPath}
+	if err := runSeparator(demucsDependency.Command, args, verbose); err != nil {
+		return "", err
+	}
+	return findVocalStem(filepath.Join(outputDir, defaultDemucsModel, "*", "vocals.wav"))
+}
+
+// separateWithAudioSeparator runs the audio-separator CLI, which uses UVR models, keeping only the
+// vocal stem. Its output files are named <track>_(Vocals)_<model>.wav.
+func separateWithAudioSeparator(audioPath, outputDir string, verbose bool) (string, error) {
+	args := []string{audioPath, "--output_dir", outputDir, "--single_stem", "Vocals", "--output_format", "WAV"}
+	if err := runSeparator(audioSeparatorDependency.Command, args, verbose); err != nil {
+		return "", err
+	}
+	return findVocalStem(filepath.Join(outputDir, "*Vocals*.wav"))
+}
+
+// isolateVocals extracts the vocal stem of audioPath into a temporary directory so Whisper does not
+// hallucinate on dense instrumentals. Separators keep the stem sample-aligned with the mix, so
+// timestamps stay relative to the original file. The returned cleanup removes the stem.
+func isolateVocals(audioPath string, config *Config) (string, func(), error) {
+	separator, err := findVocalSeparator(config.Separator)
+	if err != nil {
+		return "", nil, newError("select vocal separator", err)
+	}
+
+	processing("Isolating vocals with " + separator.Name + "...")
+
+	tmpDir, err := os.MkdirTemp("", "echowave-vocals-*")
+	if err != nil {
+		return "", nil, newError("create temp directory", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			warning(fmt.Sprintf("Failed to cleanup temp files: %v", err))
+		}
+	}
+
+	stemPath, err := separator.Separate(audioPath, tmpDir, config.Verbose)
+	if err != nil {
+		cleanup()
+		return "", nil, newError("isolate vocals", err)
+	}
+
+	success("Vocal stem extracted")
+	return stemPath, cleanup, nil
+}
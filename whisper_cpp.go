@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	whisperCppModelDirEnv     = "WHISPER_CPP_MODEL_DIR"
+	defaultWhisperCppModelDir = "models"
+)
+
+var ErrWhisperCppModelNotFound = errors.New("whisper.cpp model file not found")
+
+var whisperCppModelNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// whisperCppOffsets holds a start/end pair in milliseconds as written by whisper.cpp.
+type whisperCppOffsets struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// whisperCppToken is a single decoder token from whisper.cpp's full JSON output.
+type whisperCppToken struct {
+	Text        string            `json:"text"`
+	Offsets     whisperCppOffsets `json:"offsets"`
+	Probability float64           `json:"p"`
+}
+
+// whisperCppSegment is one transcription entry from whisper.cpp's full JSON output.
+type whisperCppSegment struct {
+	Offsets whisperCppOffsets `json:"offsets"`
+	Text    string            `json:"text"`
+	Tokens  []whisperCppToken `json:"tokens"`
+}
+
+// whisperCppOutput is the document written by whisper.cpp with --output-json-full.
+type whisperCppOutput struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []whisperCppSegment `json:"transcription"`
+}
+
+// whisperCppTranscriber drives the whisper.cpp CLI (whisper-cli, or a legacy main binary via -whisper-cpp-bin).
+type whisperCppTranscriber struct{}
+
+func (whisperCppTranscriber) Name() string {
+	return "whisper-cpp"
+}
+
+func (whisperCppTranscriber) Dependencies(config *Config) []Dependency {
+	return []Dependency{whisperCppBinaryDependency(config)}
+}
+
+// CacheKey identifies the whisper.cpp executable and the ggml model file -model and -model-dir resolve to,
+// so a replaced or differently located model is not answered from the cache.
+func (whisperCppTranscriber) CacheKey(config *Config) []string {
+	modelPath, err := resolveWhisperCppModel(config.Model, config.ModelDir)
+	if err != nil {
+		modelPath = config.Model
+	}
+	return []string{commandIdentity(whisperCppBinaryDependency(config).Command), fileIdentity(modelPath)}
+}
+
+// whisperCppBinaryDependency returns the whisper.cpp dependency, honoring -whisper-cpp-bin.
+func whisperCppBinaryDependency(config *Config) Dependency {
+	dep := whisperCppDependency
+	if config.WhisperCppBinary != "" {
+		dep.Command = config.WhisperCppBinary
+	}
+	return dep
+}
+
+// resolveWhisperCppModel maps the -model option to a ggml model file. Paths (anything ending in .bin
+// or containing a separator) are used directly; names such as "medium" or "base.en" resolve to
+// ggml-<name>.bin inside -model-dir, $WHISPER_CPP_MODEL_DIR or ./models, in that order.
+func resolveWhisperCppModel(model, modelDir string) (string, error) {
+	path := model
+	if !strings.HasSuffix(model, ".bin") && !strings.ContainsRune(model, filepath.Separator) {
+		if !whisperCppModelNamePattern.MatchString(model) {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedWhisperModel, model)
+		}
+		dir := firstNonEmpty(modelDir, os.Getenv(whisperCppModelDirEnv), defaultWhisperCppModelDir)
+		path = filepath.Join(dir, "ggml-"+model+".bin")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrWhisperCppModelNotFound, path)
+	}
+	return path, nil
+}
+
+// convertToWhisperCppWAV uses ffmpeg to produce the 16 kHz mono PCM WAV that whisper.cpp expects.
+func convertToWhisperCppWAV(audioPath, wavPath string, verbose bool) error {
+	cmd := exec.Command("ffmpeg", "-y", "-loglevel", "error", "-i", audioPath,
+		"-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", wavPath)
+	if verbose {
+		cmd.Stdout = uiWriter
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		return newError("convert audio for whisper.cpp", err)
+	}
+	return nil
+}
+
+// isWhisperCppSpecialToken reports whether a token is a control token such as [_BEG_] or [_TT_150].
+func isWhisperCppSpecialToken(text string) bool {
+	return strings.HasPrefix(text, "[_") && strings.HasSuffix(text, "]")
+}
+
+// whisperCppWords merges sub-word tokens into words. A token starting with a space begins a new word;
+// word timing spans its tokens and the probability is the mean of their probabilities.
+func whisperCppWords(tokens []whisperCppToken) []Word {
+	var words []Word
+	tokenCount := 0
+
+	for _, token := range tokens {
+		if token.Text == "" || isWhisperCppSpecialToken(token.Text) {
+			continue
+		}
+
+		start := float64(token.Offsets.From) / millisecondsPerUnit
+		end := float64(token.Offsets.To) / millisecondsPerUnit
+
+		if len(words) == 0 || strings.HasPrefix(token.Text, " ") {
+			if n := len(words); n > 0 {
+				words[n-1].Probability /= float64(tokenCount)
+			}
+			words = append(words, Word{Word: token.Text, Start: start, End: end, Probability: token.Probability})
+			tokenCount = 1
+			continue
+		}
+
+		last := &words[len(words)-1]
+		last.Word += token.Text
+		last.End = end
+		last.Probability += token.Probability
+		tokenCount++
+	}
+
+	if n := len(words); n > 0 {
+		words[n-1].Probability /= float64(tokenCount)
+	}
+
+	return words
+}
+
+// convertWhisperCppOutput maps whisper.cpp's full JSON output onto the WhisperOutput model.
+// Segment confidence is the mean word probability since whisper.cpp does not report avg_logprob.
+func convertWhisperCppOutput(raw *whisperCppOutput) *WhisperOutput {
+	output := &WhisperOutput{Language: raw.Result.Language}
+
+	for _, entry := range raw.Transcription {
+		words := whisperCppWords(entry.Tokens)
+		segment := Segment{
+			Start: float64(entry.Offsets.From) / millisecondsPerUnit,
+			End:   float64(entry.Offsets.To) / millisecondsPerUnit,
+			Text:  entry.Text,
+			Words: words,
+		}
+
+		if len(words) > 0 {
+			total := 0.0
+			for _, word := range words {
+				total += word.Probability
+			}
+			segment.Confidence = total / float64(len(words))
+		}
+
+		if strings.TrimSpace(segment.Text) != "" {
+			output.Segments = append(output.Segments, segment)
+		}
+	}
+
+	return output
+}
+
+// Transcribe converts the input to WAV, runs whisper.cpp with full JSON output and parses the result.
+// whisper.cpp accepts "-l auto" natively; the detected language probability is read from its log output.
+func (whisperCppTranscriber) Transcribe(audioPath string, config *Config) (*WhisperOutput, error) {
+	processing("Running whisper.cpp transcription...")
+
+	modelPath, err := resolveWhisperCppModel(config.Model, config.ModelDir)
+	if err != nil {
+		return nil, newError("validate whisper.cpp model", err)
+	}
+
+	step("Model: " + modelPath + ", Language: " + config.Language)
+
+	tmpDir, err := os.MkdirTemp("", "echowave-whisper-cpp-*")
+	if err != nil {
+		return nil, newError("create temp directory", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	wavPath := filepath.Join(tmpDir, "audio.wav")
+	if err := convertToWhisperCppWAV(audioPath, wavPath, config.Verbose); err != nil {
+		return nil, err
+	}
+
+	outputBase := filepath.Join(tmpDir, "transcript")
+	args := []string{"-m", modelPath, "-f", wavPath, "-l", config.Language, "--output-json-full", "--output-file", outputBase}
+	if config.Task == taskTranslate {
+		args = append(args, "--translate")
+	}
+	if config.Prompt != "" {
+		args = append(args, "--prompt", config.Prompt)
+	}
+
+	cmd := exec.Command(whisperCppBinaryDependency(config).Command, args...)
+
+	var logs bytes.Buffer
+	cmd.Stdout = uiWriter
+	cmd.Stderr = io.MultiWriter(os.Stderr, &logs)
+
+	if err := cmd.Run(); err != nil {
+		return nil, newError("run whisper.cpp transcription", err)
+	}
+
+	data, err := os.ReadFile(outputBase + ".json")
+	if err != nil {
+		return nil, newError("read whisper.cpp output", err)
+	}
+
+	var raw whisperCppOutput
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, newError("parse whisper.cpp output", err)
+	}
+
+	output := convertWhisperCppOutput(&raw)
+	if language, probability := parseWhisperCppDetectedLanguage(logs.String()); language != "" {
+		output.Language = language
+		output.LanguageProbability = probability
+	}
+	if len(output.Segments) == 0 {
+		return nil, newError("process transcription", ErrNoSegmentsFound)
+	}
+
+	success("whisper.cpp transcription completed")
+	return output, nil
+}